@@ -0,0 +1,369 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	stdhttp "net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+)
+
+// Exit codes for the config subcommand.
+const (
+	exitOK      = 0 // Command succeeded
+	exitInvalid = 1 // Configuration failed to load or validate
+	exitUsage   = 2 // Bad arguments
+)
+
+// remoteStandInReadHeaderTimeout bounds slow clients of the local stand-in.
+const remoteStandInReadHeaderTimeout = 5 * time.Second
+
+const configUsage = `Usage: service config <command> [flags]
+
+Commands:
+  show      [--profile NAME]             Print the merged configuration (secrets redacted)
+  validate  [--profile NAME | --all]     Load and validate one or all profiles
+  explain   KEY [--profile NAME]         Show the value of KEY and which layer supplied it
+  diff      PROFILE PROFILE              Print the keys whose values differ between two profiles
+  schema    [--output FILE]              Print the JSON Schema for config files
+  serve-remote [--dir DIR] [--addr ADDR] Serve DIR as a local remote-config store (/v1/kv/{key})
+
+The profile defaults to $APP_ENVIRONMENT, or "local" if unset.
+`
+
+// runConfigCommand implements `service config ...` for inspecting and
+// validating configuration without starting the server. It returns the
+// process exit code.
+func runConfigCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "show":
+		return configShow(args[1:], stdout, stderr)
+	case "validate":
+		return configValidate(args[1:], stdout, stderr)
+	case "explain":
+		return configExplain(args[1:], stdout, stderr)
+	case "diff":
+		return configDiff(args[1:], stdout, stderr)
+	case "schema":
+		return configSchema(args[1:], stdout, stderr)
+	case "serve-remote":
+		return configServeRemote(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, configUsage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "unknown config command %q\n\n%s", args[0], configUsage)
+		return exitUsage
+	}
+}
+
+// configShow prints the merged configuration for a profile as YAML.
+func configShow(args []string, stdout, stderr io.Writer) int {
+	fs, profile := newConfigFlagSet("show", stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	cfg, err := config.Load(*profile)
+	if err != nil {
+		fmt.Fprintf(stderr, "loading config: %v\n", err)
+		return exitInvalid
+	}
+
+	out, err := cfg.RedactedYAML()
+	if err != nil {
+		fmt.Fprintf(stderr, "rendering config: %v\n", err)
+		return exitInvalid
+	}
+
+	_, _ = stdout.Write(out)
+
+	return exitOK
+}
+
+// configValidate loads and validates one profile, or every profile with --all.
+// Each profile is reported on its own line so CI logs show all failures at once.
+func configValidate(args []string, stdout, stderr io.Writer) int {
+	fs, profile := newConfigFlagSet("validate", stderr)
+	all := fs.Bool("all", false, "validate every profile in the configs directory")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	profiles := []string{*profile}
+
+	if *all {
+		var err error
+
+		profiles, err = config.Profiles()
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitInvalid
+		}
+
+		if len(profiles) == 0 {
+			fmt.Fprintln(stderr, "no profiles found")
+			return exitInvalid
+		}
+	}
+
+	code := exitOK
+
+	for _, name := range profiles {
+		warnings, err := validateProfile(name)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: invalid\n  %s\n", name, strings.ReplaceAll(err.Error(), "\n", "\n  "))
+
+			code = exitInvalid
+
+			continue
+		}
+
+		fmt.Fprintf(stdout, "%s: ok\n", name)
+
+		for _, w := range warnings {
+			fmt.Fprintf(stdout, "  warning: %s\n", w)
+		}
+	}
+
+	return code
+}
+
+// validateProfile checks a profile's files against the schema, then loads
+// and validates the merged result. Unknown keys in non-strict profiles and
+// cross-field rule warnings are returned as warnings.
+func validateProfile(profile string) ([]string, error) {
+	if err := config.ValidateProfileFiles(profile); err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(profile)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0, len(cfg.UnknownKeys()))
+	for _, u := range cfg.UnknownKeys() {
+		warnings = append(warnings, u.String())
+	}
+
+	return append(warnings, cfg.Warnings()...), nil
+}
+
+// configExplain prints the effective value of a key and the layer that
+// supplied it. A section key such as "server" explains every key beneath it.
+func configExplain(args []string, stdout, stderr io.Writer) int {
+	fs, profile := newConfigFlagSet("explain", stderr)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	// Allow flags on either side of the key: explain server.port --profile prod
+	if fs.NArg() == 0 {
+		fmt.Fprintf(stderr, "explain requires a key\n\n%s", configUsage)
+		return exitUsage
+	}
+
+	key := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return exitUsage
+	}
+
+	cfg, err := config.Load(*profile)
+	if err != nil {
+		fmt.Fprintf(stderr, "loading config: %v\n", err)
+		return exitInvalid
+	}
+
+	keys, err := explainKeys(cfg, key)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	values := cfg.RedactedValues()
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+
+	for _, k := range keys {
+		origin, _ := cfg.Origin(k)
+		fmt.Fprintf(tw, "%s\t%v\t%s\n", k, values[k], origin)
+	}
+
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// explainKeys returns key itself if it is a leaf, or all leaf keys beneath it.
+func explainKeys(cfg *config.Config, key string) ([]string, error) {
+	var keys []string
+
+	for _, k := range cfg.Keys() {
+		if k == key || strings.HasPrefix(k, key+".") {
+			keys = append(keys, k)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("unknown config key " + key)
+	}
+
+	return keys, nil
+}
+
+// unsetValue marks a key missing from one side of a diff.
+const unsetValue = "(unset)"
+
+// configDiff prints the keys whose effective values differ between two
+// profiles, with both values. Secrets are compared redacted, so a secret that
+// differs only in value is not reported.
+func configDiff(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 2 {
+		fmt.Fprintf(stderr, "diff requires two profiles\n\n%s", configUsage)
+		return exitUsage
+	}
+
+	values := make([]map[string]any, len(args))
+
+	for i, profile := range args {
+		cfg, err := config.Load(profile)
+		if err != nil {
+			fmt.Fprintf(stderr, "loading config %s: %v\n", profile, err)
+			return exitInvalid
+		}
+
+		values[i] = cfg.RedactedValues()
+	}
+
+	keys := slices.Concat(slices.Collect(maps.Keys(values[0])), slices.Collect(maps.Keys(values[1])))
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "KEY\t%s\t%s\n", args[0], args[1])
+
+	differences := 0
+
+	for _, key := range keys {
+		a, b := diffValue(values[0], key), diffValue(values[1], key)
+		if a == b {
+			continue
+		}
+
+		differences++
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", key, a, b)
+	}
+
+	if differences == 0 {
+		fmt.Fprintf(stdout, "%s and %s have the same configuration\n", args[0], args[1])
+		return exitOK
+	}
+
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// diffValue formats the value of key for configDiff.
+func diffValue(values map[string]any, key string) string {
+	value, ok := values[key]
+	if !ok {
+		return unsetValue
+	}
+
+	return fmt.Sprint(value)
+}
+
+// configSchema writes the JSON Schema for config files to stdout or --output.
+func configSchema(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("config schema", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	output := fs.String("output", "", "write the schema to this file instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	schema, err := config.JSONSchema()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	if *output == "" {
+		_, _ = stdout.Write(schema)
+		return exitOK
+	}
+
+	if err := os.WriteFile(*output, schema, 0o644); err != nil { //nolint:gosec // Schema is public, editors must read it
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// configServeRemote serves a directory of YAML/JSON documents as a stand-in
+// for a central config store, until interrupted.
+func configServeRemote(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("config serve-remote", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	dir := fs.String("dir", "configs/remote", "directory of documents, served as /v1/kv/{key}")
+	addr := fs.String("addr", "127.0.0.1:8500", "listen address")
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	server := &stdhttp.Server{
+		Addr:              *addr,
+		Handler:           config.NewRemoteStandIn(*dir),
+		ReadHeaderTimeout: remoteStandInReadHeaderTimeout,
+	}
+
+	fmt.Fprintf(stdout, "serving %s at http://%s/v1/kv/{key}\n", *dir, *addr)
+
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// newConfigFlagSet creates a flag set with the shared --profile flag.
+func newConfigFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("config "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	profile := fs.String("profile", defaultProfile(), "config profile to load")
+
+	return fs, profile
+}
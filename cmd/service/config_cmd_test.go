@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigShow_RedactsSecretFields tests that secret fields are redacted even when set as plain values.
+func TestConfigShow_RedactsSecretFields(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("APP_TELEMETRY_BEARER_TOKEN", "tok123")
+	t.Setenv("APP_TELEMETRY_HEADERS_TENANT", "hdr-456")
+
+	var stdout, stderr bytes.Buffer
+
+	code := runConfigCommand([]string{"show", "--profile", "local"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	assert.NotContains(t, stdout.String(), "tok123")
+	assert.NotContains(t, stdout.String(), "hdr-456")
+	assert.Contains(t, stdout.String(), "bearer_token: '[REDACTED]'")
+	assert.Contains(t, stdout.String(), "tenant: '[REDACTED]'")
+}
+
+// writeProfile writes a profile config file into the configs directory of the
+// current working directory.
+func writeProfile(t *testing.T, profile, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll("configs", 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join("configs", profile+".yaml"), []byte(content), 0o600))
+}
+
+// TestConfigValidate tests that validate reports each profile and fails when any is invalid.
+func TestConfigValidate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeProfile(t, "good", "log:\n  level: debug\n")
+	writeProfile(t, "bad", "log:\n  level: verbose\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     []string
+	}{
+		{
+			name:     "valid profile",
+			args:     []string{"validate", "--profile", "good"},
+			wantCode: exitOK,
+			want:     []string{"good: ok"},
+		},
+		{
+			name:     "invalid profile",
+			args:     []string{"validate", "--profile", "bad"},
+			wantCode: exitInvalid,
+			want:     []string{"bad: invalid", "log.level"},
+		},
+		{
+			name:     "all profiles",
+			args:     []string{"validate", "--all"},
+			wantCode: exitInvalid,
+			want:     []string{"bad: invalid", "good: ok"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := runConfigCommand(tt.args, &stdout, &stderr)
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+
+			for _, want := range tt.want {
+				assert.Contains(t, stdout.String(), want)
+			}
+		})
+	}
+}
+
+// TestConfigDiff tests that diff prints only the keys that differ, with secrets redacted.
+func TestConfigDiff(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeProfile(t, "one", "log:\n  level: debug\ntelemetry:\n  bearer_token: tok-one\n")
+	writeProfile(t, "two", "log:\n  level: warn\ntelemetry:\n  bearer_token: tok-two\n")
+
+	var stdout, stderr bytes.Buffer
+
+	code := runConfigCommand([]string{"diff", "one", "two"}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	assert.Contains(t, stdout.String(), "KEY")
+	assert.Regexp(t, `log\.level\s+debug\s+warn`, stdout.String())
+	assert.NotContains(t, stdout.String(), "tok-")
+	assert.NotContains(t, stdout.String(), "server.port")
+}
+
+// TestConfigDiff_RequiresTwoProfiles tests that diff rejects a missing profile argument.
+func TestConfigDiff_RequiresTwoProfiles(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runConfigCommand([]string{"diff", "local"}, &stdout, &stderr)
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr.String(), "two profiles")
+}
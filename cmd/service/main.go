@@ -10,8 +10,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+
+	"github.com/jsamuelsen/go-service-template/internal/adapters/audit"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/clients"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/clients/acl"
+	"github.com/jsamuelsen/go-service-template/internal/adapters/featureflags"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/handlers"
 	"github.com/jsamuelsen/go-service-template/internal/app"
@@ -35,6 +41,13 @@ var (
 )
 
 func main() {
+	// Cross-field config rules that depend on adapter settings
+	config.RegisterRule(config.WriteTimeoutRule(http.DefaultRequestTimeout))
+
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
@@ -42,13 +55,11 @@ func main() {
 }
 
 func run() error {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// 1. Determine profile from environment
-	profile := os.Getenv("APP_ENVIRONMENT")
-	if profile == "" {
-		profile = "local"
-	}
+	profile := defaultProfile()
 
 	// 2. Load and validate configuration (fail fast)
 	cfg, err := config.Load(profile)
@@ -60,12 +71,76 @@ func run() error {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
-	// 3. Initialize logging
+	// 3. Initialize logging (level is adjustable at runtime via config reload,
+	// and per component via the /-/log-levels admin endpoint)
+	logLevel := new(slog.LevelVar)
+	logLevels := logging.NewLevels()
+
+	// Exported logs go through the global provider, which forwards to the
+	// SDK provider once telemetry is initialized below
+	var logProvider otellog.LoggerProvider
+	if cfg.Telemetry.Enabled && cfg.Telemetry.Logs {
+		logProvider = global.GetLoggerProvider()
+	}
+
+	// Recent records are kept in memory for /-/logs when enabled
+	var logBuffer *logging.RingBuffer
+	if cfg.Log.Buffer.Enabled {
+		logBuffer = logging.NewRingBuffer(cfg.Log.Buffer.Size)
+	}
+
+	// Records are written in the background when enabled; the queue is
+	// flushed by waitForShutdown. Its metrics go through the global meter
+	// provider, like exported logs
+	var logQueue *logging.AsyncQueue
+	if cfg.Log.Async.Enabled {
+		logQueue, err = logging.NewAsyncQueue(logging.AsyncConfig{
+			Size:   cfg.Log.Async.QueueSize,
+			Policy: logging.OverflowPolicy(cfg.Log.Async.Policy),
+		})
+		if err != nil {
+			return fmt.Errorf("creating log queue: %w", err)
+		}
+	}
+
+	// Repetitive records are dropped when enabled; the summary of the last
+	// window is logged by waitForShutdown
+	var logSampler *logging.Sampler
+	if cfg.Log.Sampling.Enabled {
+		logSampler = logging.NewSampler(logging.SamplingConfig{
+			Window:     cfg.Log.Sampling.Window,
+			First:      cfg.Log.Sampling.First,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Dedup:      cfg.Log.Sampling.Dedup,
+		})
+	}
+
+	redactPatterns, err := cfg.Log.Redact.Regexps()
+	if err != nil {
+		return fmt.Errorf("compiling redaction patterns: %w", err)
+	}
+
+	redactPolicy := logging.RedactConfig{
+		Fields:    cfg.Log.Redact.Fields,
+		Patterns:  redactPatterns,
+		Detectors: cfg.Log.Redact.Detectors,
+		Style:     logging.RedactStyle(cfg.Log.Redact.Style),
+		HashKey:   cfg.Log.Redact.HashKey,
+	}
+
+	// Same rules as the logger, for values parsed before logging
+	// (query parameters, headers, downstream error bodies)
+	redactor := logging.NewRedactor(cfg.SecretValues(), redactPolicy)
+
 	logger := logging.New(&logging.Config{
-		Level:   cfg.Log.Level,
-		Format:  cfg.Log.Format,
-		Service: cfg.App.Name,
-		Version: cfg.App.Version,
+		LevelVar: logLevel,
+		Levels:   logLevels,
+		Level:    cfg.Log.Level,
+		Format:   cfg.Log.Format,
+		Service:  cfg.App.Name,
+		Version:  cfg.App.Version,
+		Secrets:  cfg.SecretValues(),
+		Redact:   redactPolicy,
 		File: logging.FileConfig{
 			Enabled:    cfg.Log.File.Enabled,
 			Path:       cfg.Log.File.Path,
@@ -74,8 +149,12 @@ func run() error {
 			MaxAgeDays: cfg.Log.File.MaxAgeDays,
 			Compress:   cfg.Log.File.Compress,
 		},
+		Sampler:        logSampler,
+		LoggerProvider: logProvider,
+		Buffer:         logBuffer,
+		Async:          logQueue,
 	})
-	slog.SetDefault(logger)
+	logging.SetDefault(logger)
 
 	logger.Info("starting service",
 		slog.String("version", Version),
@@ -83,15 +162,58 @@ func run() error {
 		slog.String("environment", cfg.App.Environment),
 	)
 
-	// 4. Initialize telemetry (noop if disabled)
-	telProvider, err := telemetry.New(ctx, &telemetry.Config{
+	// Unknown keys are fatal in strict profiles; elsewhere they only warn
+	for _, u := range cfg.UnknownKeys() {
+		logger.Warn("ignoring unknown config key", slog.String("detail", u.String()))
+	}
+
+	for _, warning := range cfg.Warnings() {
+		logger.Warn("config warning", slog.String("detail", warning))
+	}
+
+	// 4. Initialize telemetry (only the Prometheus metrics reader if disabled)
+	telCfg := &telemetry.Config{
 		Enabled:      cfg.Telemetry.Enabled,
+		Exporter:     telemetry.Exporter(cfg.Telemetry.Exporter),
 		Endpoint:     cfg.Telemetry.Endpoint,
 		ServiceName:  cfg.Telemetry.ServiceName,
 		Version:      cfg.App.Version,
 		Environment:  cfg.App.Environment,
 		SamplingRate: cfg.Telemetry.SamplingRate,
-	})
+		Sampling: telemetry.SamplingConfig{
+			Routes:     cfg.Telemetry.Sampling.Routes,
+			RateLimit:  cfg.Telemetry.Sampling.RateLimit,
+			KeepErrors: cfg.Telemetry.Sampling.KeepErrors,
+		},
+		Logs: cfg.Telemetry.Logs,
+		TLS: telemetry.TLSConfig{
+			CAFile:     cfg.Telemetry.TLS.CAFile,
+			CertFile:   cfg.Telemetry.TLS.CertFile,
+			KeyFile:    cfg.Telemetry.TLS.KeyFile,
+			ServerName: cfg.Telemetry.TLS.ServerName,
+			MinVersion: cfg.Telemetry.TLS.MinVersion,
+		},
+		Headers:     cfg.Telemetry.Headers,
+		BearerToken: cfg.Telemetry.BearerToken,
+		OTLP: telemetry.OTLPConfig{
+			Compression: cfg.Telemetry.OTLP.Compression,
+			Timeout:     cfg.Telemetry.OTLP.Timeout,
+		},
+		Stdout: telemetry.StdoutConfig{
+			Pretty:  cfg.Telemetry.Stdout.Pretty,
+			Metrics: cfg.Telemetry.Stdout.Metrics,
+		},
+		File: telemetry.FileConfig{
+			Path:   cfg.Telemetry.File.Path,
+			Pretty: cfg.Telemetry.File.Pretty,
+		},
+		MetricsInterval: cfg.Telemetry.MetricsInterval,
+	}
+	if cfg.Telemetry.Prometheus {
+		telCfg.Prometheus = prometheus.DefaultRegisterer
+	}
+
+	telProvider, err := telemetry.New(ctx, telCfg)
 	if err != nil {
 		return fmt.Errorf("initializing telemetry: %w", err)
 	}
@@ -105,41 +227,97 @@ func run() error {
 	// 5. Create health registry
 	healthRegistry := ports.NewHealthRegistry()
 
-	// 6. Create HTTP client for downstream services
-	httpClient, err := clients.New(&clients.Config{
-		BaseURL:     cfg.Services.Quote.BaseURL,
-		ServiceName: cfg.Services.Quote.Name,
-		Timeout:     cfg.Client.Timeout,
-		Retry:       cfg.Client.Retry,
-		Circuit:     cfg.Client.CircuitBreaker,
-		Transport:   cfg.Client.Transport,
-		Logger:      logger,
+	// 6. Create HTTP clients for downstream services (one per services.* entry)
+	clientRegistry, err := clients.NewRegistry(clients.RegistryConfig{
+		Services: cfg.Services,
+		Logger:   logger,
 	})
 	if err != nil {
-		return fmt.Errorf("creating HTTP client: %w", err)
+		return fmt.Errorf("creating HTTP clients: %w", err)
+	}
+
+	// Register each downstream client as a health checker
+	if err := clientRegistry.RegisterHealthChecks(healthRegistry); err != nil {
+		return fmt.Errorf("registering downstream health checks: %w", err)
 	}
 
 	// 7. Create quote client adapter (ACL pattern)
+	quoteHTTPClient, err := clientRegistry.Client("quote")
+	if err != nil {
+		return fmt.Errorf("creating quote client: %w", err)
+	}
+
 	quoteClient := acl.NewQuoteClient(acl.QuoteClientConfig{
-		Client: httpClient,
-		Logger: logger,
+		Client:   quoteHTTPClient,
+		Logger:   logger,
+		Redactor: redactor,
+	})
+
+	// Feature flags backed by the `features` config section, injected into
+	// application services via ports.FeatureFlags and updated on reload
+	featureFlags := featureflags.NewConfigFlags(cfg.Features)
+
+	// Hot-reload configuration on file changes and SIGHUP
+	configWatcher, err := config.NewWatcher(config.WatcherConfig{
+		Profile: profile,
+		Initial: cfg,
+		Logger:  logger,
 	})
+	if err != nil {
+		return fmt.Errorf("creating config watcher: %w", err)
+	}
 
-	// Register quote client as a health checker
-	if err := healthRegistry.Register(quoteClient); err != nil {
-		return fmt.Errorf("registering quote client health check: %w", err)
+	configWatcher.Subscribe(func(_, next *config.Config) {
+		logging.SetLevel(logLevel, next.Log.Level)
+		clientRegistry.Reconfigure(next.Services)
+		featureFlags.Update(next.Features)
+	})
+
+	if err := configWatcher.Start(ctx); err != nil {
+		return fmt.Errorf("starting config watcher: %w", err)
 	}
 
 	// 8. Create quote service (application layer)
 	quoteService := app.NewQuoteService(app.QuoteServiceConfig{
 		QuoteClient: quoteClient,
+		Flags:       featureFlags,
 		Logger:      logger,
 	})
 
 	// 9. Create handlers
 	buildInfo := handlers.NewBuildInfo(Version, Commit, BuildTime)
 	healthHandler := handlers.NewHealthHandler(healthRegistry, buildInfo)
+	configHandler := handlers.NewConfigHandler(configWatcher)
 	quoteHandler := handlers.NewQuoteHandler(quoteService)
+	logLevelHandler := handlers.NewLogLevelHandler(logLevels, logLevel)
+
+	var logsHandler *handlers.LogsHandler
+	if logBuffer != nil {
+		logsHandler = handlers.NewLogsHandler(logBuffer)
+	}
+
+	// Audit trail of authorization decisions and commits (separate file)
+	var auditLogger ports.AuditLogger
+	if cfg.Audit.Enabled {
+		auditFile, err := audit.NewFileLogger(audit.FileConfig{
+			Path:       cfg.Audit.Path,
+			MaxSizeMB:  cfg.Audit.MaxSizeMB,
+			MaxBackups: cfg.Audit.MaxBackups,
+			MaxAgeDays: cfg.Audit.MaxAgeDays,
+			Compress:   cfg.Audit.Compress,
+		})
+		if err != nil {
+			return fmt.Errorf("creating audit logger: %w", err)
+		}
+
+		defer func() {
+			if closeErr := auditFile.Close(); closeErr != nil {
+				logger.Error("audit log close error", slog.Any("error", closeErr))
+			}
+		}()
+
+		auditLogger = auditFile
+	}
 
 	// 10. Create HTTP server
 	server := http.New(&cfg.Server, logger)
@@ -152,6 +330,14 @@ func run() error {
 		HealthHandler: healthHandler,
 		QuoteHandler:  quoteHandler,
 		Timeout:       http.DefaultRequestTimeout,
+
+		LogLevelHandler: logLevelHandler,
+		LogsHandler:     logsHandler,
+		ConfigHandler:   configHandler,
+		RequestDebug:    &cfg.Log.RequestDebug,
+		AuditLogger:     auditLogger,
+		LogRedactor:     redactor,
+		LogHeaders:      cfg.Log.Headers,
 	}
 	http.SetupRouter(server.Engine(), routerCfg)
 
@@ -159,14 +345,27 @@ func run() error {
 	serverErr := server.Start()
 
 	// 13. Wait for shutdown signal
-	return waitForShutdown(ctx, logger, server, serverErr, cfg.Server.ShutdownTimeout)
+	return waitForShutdown(ctx, logger, logSampler, logQueue, server, serverErr, cfg.Server.ShutdownTimeout)
+}
+
+// defaultProfile returns the config profile selected by APP_ENVIRONMENT,
+// falling back to "local".
+func defaultProfile() string {
+	if profile := os.Getenv("APP_ENVIRONMENT"); profile != "" {
+		return profile
+	}
+
+	return "local"
 }
 
 // waitForShutdown blocks until a shutdown signal is received or server error occurs.
-// It then performs graceful shutdown of the HTTP server.
+// It then performs graceful shutdown of the HTTP server, logs the last log
+// sampling summary and flushes the async log queue, if any.
 func waitForShutdown(
 	ctx context.Context,
 	logger *slog.Logger,
+	logSampler *logging.Sampler,
+	logQueue *logging.AsyncQueue,
 	server *http.Server,
 	serverErr <-chan error,
 	shutdownTimeout time.Duration,
@@ -177,7 +376,18 @@ func waitForShutdown(
 
 	select {
 	case err := <-serverErr:
-		// Server error during startup or runtime
+		// Server error during startup or runtime; write out records queued before it
+		if logSampler != nil {
+			logSampler.Close()
+		}
+
+		if logQueue != nil {
+			flushCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+			defer cancel()
+
+			_ = logQueue.Shutdown(flushCtx)
+		}
+
 		return fmt.Errorf("server error: %w", err)
 
 	case sig := <-quit:
@@ -200,5 +410,16 @@ func waitForShutdown(
 
 	logger.Info("shutdown complete")
 
+	if logSampler != nil {
+		logSampler.Close()
+	}
+
+	// Write out queued log records; later records are written synchronously
+	if logQueue != nil {
+		if err := logQueue.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("log queue shutdown: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,100 @@
+// Package audit provides adapters implementing ports.AuditLogger.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+// auditDirPerms is the permission mode for the audit file directory.
+const auditDirPerms = 0o750
+
+// Compile-time check that FileLogger implements ports.AuditLogger.
+var _ ports.AuditLogger = (*FileLogger)(nil)
+
+// FileConfig configures the rolling audit file.
+type FileConfig struct {
+	Path       string // Audit file path
+	MaxSizeMB  int    // Max size in MB before rotation
+	MaxBackups int    // Rotated files to keep; 0 keeps all
+	MaxAgeDays int    // Days to keep rotated files; 0 keeps them forever
+	Compress   bool   // Gzip rotated files
+}
+
+// FileLogger implements ports.AuditLogger by appending one JSON object per
+// event to a rolling file. The file is separate from the application log so
+// it can be shipped and retained under its own policy, and audit events are
+// never sampled, leveled or dropped like log records.
+type FileLogger struct {
+	mu  sync.Mutex
+	w   io.WriteCloser
+	now func() time.Time
+}
+
+// NewFileLogger creates an audit logger writing to the file in cfg,
+// creating its directory if needed.
+func NewFileLogger(cfg FileConfig) (*FileLogger, error) {
+	if dir := filepath.Dir(cfg.Path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, auditDirPerms); err != nil {
+			return nil, fmt.Errorf("creating audit directory: %w", err)
+		}
+	}
+
+	return NewWriterLogger(&lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+		LocalTime:  false, // Audit timestamps and backup names stay in UTC
+	}), nil
+}
+
+// NewWriterLogger creates an audit logger writing JSON lines to w.
+// Useful for tests or for shipping the trail to stdout.
+func NewWriterLogger(w io.WriteCloser) *FileLogger {
+	return &FileLogger{w: w, now: time.Now}
+}
+
+// Record appends the event as a single JSON line. Time is set to now if zero.
+func (l *FileLogger) Record(_ context.Context, event ports.AuditEvent) error { //nolint:gocritic // ports.AuditLogger interface takes value
+	if event.Time.IsZero() {
+		event.Time = l.now()
+	}
+
+	event.Time = event.Time.UTC()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding audit event: %w", err)
+	}
+
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.w.Write(line); err != nil {
+		return fmt.Errorf("writing audit event: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the underlying file.
+func (l *FileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Close()
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+func TestFileLogger_WritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+
+	logger, err := NewFileLogger(FileConfig{Path: path, MaxSizeMB: 1})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	require.NoError(t, logger.Record(ctx, ports.AuditEvent{
+		Time:      at,
+		Subject:   "user-123",
+		Action:    ports.AuditActionAuthorize,
+		Resource:  "GET /-/logs",
+		Decision:  ports.AuditDecisionDeny,
+		Outcome:   ports.AuditOutcomeDenied,
+		Reason:    "insufficient permissions: role admin required",
+		RequestID: "req-1",
+		Details:   map[string]any{"role": "admin"},
+	}))
+	require.NoError(t, logger.Record(ctx, ports.AuditEvent{
+		Subject:  "user-123",
+		Action:   ports.AuditActionCommit,
+		Resource: "POST /api/v1/quotes",
+		Outcome:  ports.AuditOutcomeSuccess,
+	}))
+	require.NoError(t, logger.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var lines []map[string]any
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, "2024-01-15T15:30:00Z", lines[0]["time"], "times are written in UTC")
+	assert.Equal(t, "user-123", lines[0]["subject"])
+	assert.Equal(t, "authorize", lines[0]["action"])
+	assert.Equal(t, "GET /-/logs", lines[0]["resource"])
+	assert.Equal(t, "deny", lines[0]["decision"])
+	assert.Equal(t, "denied", lines[0]["outcome"])
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, map[string]any{"role": "admin"}, lines[0]["details"])
+
+	assert.Equal(t, "commit", lines[1]["action"])
+	assert.NotEmpty(t, lines[1]["time"], "zero time is set on record")
+	assert.NotContains(t, lines[1], "decision")
+}
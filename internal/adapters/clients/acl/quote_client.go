@@ -24,14 +24,19 @@ type QuoteClientConfig struct {
 
 	// Logger is the structured logger.
 	Logger *slog.Logger
+
+	// Redactor redacts error response bodies before they are logged.
+	// Defaults to the built-in secret rules.
+	Redactor *logging.Redactor
 }
 
 // QuoteClient implements ports.QuoteClient using the quotable.io API.
 // It demonstrates the ACL pattern by translating external API responses
 // to domain types.
 type QuoteClient struct {
-	client *clients.Client
-	logger *slog.Logger
+	client   *clients.Client
+	logger   *slog.Logger
+	redactor *logging.Redactor
 }
 
 // NewQuoteClient creates a new quote client adapter.
@@ -46,9 +51,15 @@ func NewQuoteClient(cfg QuoteClientConfig) *QuoteClient {
 		logger = slog.Default()
 	}
 
+	redactor := cfg.Redactor
+	if redactor == nil {
+		redactor = logging.NewRedactor(nil, logging.RedactConfig{})
+	}
+
 	return &QuoteClient{
-		client: cfg.Client,
-		logger: logger,
+		client:   cfg.Client,
+		logger:   logger,
+		redactor: redactor,
 	}
 }
 
@@ -79,7 +90,7 @@ func (c *QuoteClient) GetRandomQuote(ctx context.Context) (*domain.Quote, error)
 		slog.Int("status", resp.StatusCode))
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, c.handleErrorResponse(resp)
+		return nil, c.handleErrorResponse(ctx, resp)
 	}
 
 	return c.parseQuoteResponse(ctx, resp.Body)
@@ -109,7 +120,7 @@ func (c *QuoteClient) GetQuoteByID(ctx context.Context, id string) (*domain.Quot
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, c.handleErrorResponse(resp)
+		return nil, c.handleErrorResponse(ctx, resp)
 	}
 
 	return c.parseQuoteResponse(ctx, resp.Body)
@@ -147,12 +158,13 @@ func (c *QuoteClient) translateToDomain(ext *quotableResponse) *domain.Quote {
 }
 
 // handleErrorResponse converts HTTP error responses to domain errors.
-func (c *QuoteClient) handleErrorResponse(resp *http.Response) error {
+// The body is redacted per JSON key before it is logged.
+func (c *QuoteClient) handleErrorResponse(ctx context.Context, resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
 
-	c.logger.Warn("quote API error",
+	c.logger.WarnContext(ctx, "quote API error",
 		slog.Int("status_code", resp.StatusCode),
-		slog.String("body", string(body)),
+		slog.String("body", c.redactor.Body(body)),
 	)
 
 	switch resp.StatusCode {
@@ -164,26 +176,3 @@ func (c *QuoteClient) handleErrorResponse(resp *http.Response) error {
 		return domain.NewUnavailableError("quote-service", fmt.Sprintf("unexpected HTTP %d", resp.StatusCode))
 	}
 }
-
-// Name returns the health check name for this client.
-// Implements ports.HealthChecker.
-func (c *QuoteClient) Name() string {
-	return "quote-service"
-}
-
-// Check performs a health check by calling the API's health endpoint.
-// Implements ports.HealthChecker.
-func (c *QuoteClient) Check(ctx context.Context) error {
-	// Use a simple endpoint to verify connectivity
-	resp, err := c.client.Get(ctx, "/random")
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("quote API returned status %d", resp.StatusCode)
-	}
-
-	return nil
-}
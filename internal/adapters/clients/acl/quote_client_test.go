@@ -1,6 +1,7 @@
 package acl
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -16,6 +17,7 @@ import (
 	"github.com/jsamuelsen/go-service-template/internal/adapters/clients"
 	"github.com/jsamuelsen/go-service-template/internal/domain"
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 )
 
 // setupQuoteClient creates a QuoteClient with a test HTTP server.
@@ -101,16 +103,6 @@ func TestNewQuoteClient_DefaultsLogger(t *testing.T) {
 	assert.NotNil(t, quoteClient.logger)
 }
 
-// TestQuoteClient_Name verifies that Name returns the expected service name.
-func TestQuoteClient_Name(t *testing.T) {
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
-	client := setupQuoteClient(t, handler)
-
-	name := client.Name()
-
-	assert.Equal(t, "quote-service", name)
-}
-
 // TestGetRandomQuote_Success verifies that a random quote can be fetched successfully.
 func TestGetRandomQuote_Success(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -250,33 +242,8 @@ func TestGetQuoteByID_ServerError(t *testing.T) {
 	assert.Contains(t, err.Error(), "quote-service")
 }
 
-// TestQuoteClient_Check_Success verifies that health check passes on successful request.
-func TestQuoteClient_Check_Success(t *testing.T) {
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "/random", r.URL.Path)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		err := json.NewEncoder(w).Encode(map[string]any{
-			"_id":     "health-check",
-			"content": "Health check quote",
-			"author":  "System",
-			"tags":    []string{"health"},
-		})
-		if !assert.NoError(t, err) {
-			return
-		}
-	})
-
-	client := setupQuoteClient(t, handler)
-	ctx := context.Background()
-
-	err := client.Check(ctx)
-
-	assert.NoError(t, err)
-}
-
-// TestQuoteClient_Check_Failure verifies that health check fails on non-200 response.
-func TestQuoteClient_Check_Failure(t *testing.T) {
+// TestGetRandomQuote_ServiceUnavailable verifies that 503 error returns UnavailableError.
+func TestGetRandomQuote_ServiceUnavailable(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusServiceUnavailable)
 	})
@@ -284,26 +251,33 @@ func TestQuoteClient_Check_Failure(t *testing.T) {
 	client := setupQuoteClient(t, handler)
 	ctx := context.Background()
 
-	err := client.Check(ctx)
+	quote, err := client.GetRandomQuote(ctx)
 
 	require.Error(t, err)
+	assert.Nil(t, quote)
+	assert.True(t, domain.IsUnavailable(err))
+	assert.Contains(t, err.Error(), "quote-service")
 	assert.Contains(t, err.Error(), "503")
 }
 
-// TestGetRandomQuote_ServiceUnavailable verifies that 503 error returns UnavailableError.
-func TestGetRandomQuote_ServiceUnavailable(t *testing.T) {
+// TestGetRandomQuote_ErrorBodyRedacted verifies that the logged error body is redacted per JSON key.
+func TestGetRandomQuote_ErrorBodyRedacted(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusServiceUnavailable)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid key","api_key":"k-123456","contact":"ops@example.com"}`))
 	})
 
 	client := setupQuoteClient(t, handler)
-	ctx := context.Background()
+	client.redactor = logging.NewRedactor(nil, logging.RedactConfig{Detectors: []string{logging.DetectorEmail}})
 
-	quote, err := client.GetRandomQuote(ctx)
+	var buf bytes.Buffer
+	client.logger = slog.New(slog.NewJSONHandler(&buf, nil))
 
+	_, err := client.GetRandomQuote(context.Background())
 	require.Error(t, err)
-	assert.Nil(t, quote)
-	assert.True(t, domain.IsUnavailable(err))
-	assert.Contains(t, err.Error(), "quote-service")
-	assert.Contains(t, err.Error(), "503")
+
+	assert.Contains(t, buf.String(), "quote API error")
+	assert.Contains(t, buf.String(), "invalid key")
+	assert.NotContains(t, buf.String(), "k-123456")
+	assert.NotContains(t, buf.String(), "ops@example.com")
 }
@@ -87,6 +87,14 @@ func (cb *CircuitBreaker) OnStateChange(fn func(from, to State)) {
 	cb.onStateChange = fn
 }
 
+// SetConfig replaces the circuit breaker thresholds without resetting its state.
+// New thresholds apply from the next recorded result (e.g. after a config reload).
+func (cb *CircuitBreaker) SetConfig(cfg CircuitBreakerConfig) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.cfg = cfg
+}
+
 // Allow checks if a request should be allowed through.
 // Returns true if the request should proceed, false if it should be blocked.
 //
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -23,12 +24,17 @@ import (
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/middleware"
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
 	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+	"github.com/jsamuelsen/go-service-template/internal/platform/telemetry"
 )
 
 const (
 	// instrumentationName is used for OpenTelemetry tracer and meter.
 	instrumentationName = "github.com/jsamuelsen/go-service-template/internal/adapters/clients"
 
+	// componentName is the component attribute of client logs, the key of
+	// their per-component level override.
+	componentName = "clients.Client"
+
 	// httpStatusCategoryDivisor divides status code to get category (2xx, 4xx, 5xx).
 	httpStatusCategoryDivisor = 100
 
@@ -63,6 +69,10 @@ type Config struct {
 	// Transport configures HTTP transport pool settings.
 	Transport config.TransportConfig
 
+	// HealthPath is an optional path probed by Check. If empty, Check only
+	// reports whether the circuit breaker is open.
+	HealthPath string
+
 	// AuthFunc is an optional function to inject authentication into requests.
 	// It is called for each request attempt (including retries).
 	AuthFunc func(*http.Request)
@@ -79,6 +89,7 @@ type Config struct {
 //   - Request/correlation ID propagation
 //   - Structured logging
 type Client struct {
+	mu          sync.RWMutex // Protects http and cfg.Retry/cfg.Timeout for Reconfigure
 	http        *http.Client
 	baseURL     string
 	serviceName string
@@ -121,8 +132,8 @@ func New(cfg *Config) (*Client, error) {
 		logger = slog.Default()
 	}
 	logger = logger.With(
-		slog.String("component", "clients.Client"),
-		slog.String("downstream", cfg.ServiceName),
+		slog.String(logging.ComponentKey, componentName),
+		slog.String(logging.DownstreamKey, cfg.ServiceName),
 	)
 
 	// Set up circuit breaker logging
@@ -141,6 +152,7 @@ func New(cfg *Config) (*Client, error) {
 		"http.client.request.duration",
 		metric.WithDescription("Duration of HTTP client requests"),
 		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetry.DurationBuckets...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating duration metric: %w", err)
@@ -186,7 +198,8 @@ func New(cfg *Config) (*Client, error) {
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	startTime := time.Now()
 	logger := logging.FromContext(ctx).With(
-		slog.String("downstream", c.serviceName),
+		slog.String(logging.ComponentKey, componentName),
+		slog.String(logging.DownstreamKey, c.serviceName),
 		slog.String("method", req.Method),
 		slog.String("path", req.URL.Path),
 	)
@@ -194,7 +207,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	// Check circuit breaker
 	if !c.cb.Allow() {
 		c.recordMetrics(ctx, req.Method, 0, time.Since(startTime), "circuit_open")
-		logger.Warn("request blocked by circuit breaker")
+		logger.WarnContext(ctx, "request blocked by circuit breaker")
 		return nil, ErrCircuitOpen
 	}
 
@@ -227,16 +240,18 @@ func (c *Client) executeWithRetry(ctx context.Context, req *http.Request, logger
 	var lastErr error
 	var resp *http.Response
 
-	for attempt := 0; attempt < c.cfg.Retry.MaxAttempts; attempt++ {
+	httpClient, retry := c.settings()
+
+	for attempt := 0; attempt < retry.MaxAttempts; attempt++ {
 		if attempt > 0 {
 			if err := c.waitForRetry(ctx, req, attempt, logger, startTime); err != nil {
 				return nil, err
 			}
 		}
 
-		resp, lastErr = c.http.Do(req.WithContext(ctx))
+		resp, lastErr = httpClient.Do(req.WithContext(ctx))
 
-		if shouldRetry, err := c.handleAttemptResult(resp, lastErr, attempt, logger); shouldRetry {
+		if shouldRetry, err := c.handleAttemptResult(ctx, resp, lastErr, attempt, logger); shouldRetry {
 			lastErr = err
 			continue
 		}
@@ -254,7 +269,7 @@ func (c *Client) executeWithRetry(ctx context.Context, req *http.Request, logger
 // waitForRetry waits for the backoff duration before retrying.
 func (c *Client) waitForRetry(ctx context.Context, req *http.Request, attempt int, logger *slog.Logger, startTime time.Time) error {
 	backoff := c.calculateBackoff(attempt)
-	logger.Debug("retrying request",
+	logger.DebugContext(ctx, "retrying request",
 		slog.Int("attempt", attempt+1),
 		slog.Duration("backoff", backoff),
 	)
@@ -277,10 +292,10 @@ func (c *Client) waitForRetry(ctx context.Context, req *http.Request, attempt in
 
 // handleAttemptResult checks the response and determines if retry is needed.
 // Returns (shouldRetry, error).
-func (c *Client) handleAttemptResult(resp *http.Response, err error, attempt int, logger *slog.Logger) (bool, error) {
+func (c *Client) handleAttemptResult(ctx context.Context, resp *http.Response, err error, attempt int, logger *slog.Logger) (bool, error) {
 	if err != nil {
 		if isRetryableError(err) {
-			logger.Debug("request failed with retryable error",
+			logger.DebugContext(ctx, "request failed with retryable error",
 				slog.Int("attempt", attempt+1),
 				slog.Any("error", err),
 			)
@@ -290,12 +305,12 @@ func (c *Client) handleAttemptResult(resp *http.Response, err error, attempt int
 	}
 
 	if resp.StatusCode >= http.StatusInternalServerError {
-		logger.Debug("request failed with server error",
+		logger.DebugContext(ctx, "request failed with server error",
 			slog.Int("attempt", attempt+1),
 			slog.Int("status", resp.StatusCode),
 		)
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			logger.Debug("failed to close response body", slog.Any("error", closeErr))
+			logger.DebugContext(ctx, "failed to close response body", slog.Any("error", closeErr))
 		}
 		return true, fmt.Errorf("server error: %d", resp.StatusCode)
 	}
@@ -311,7 +326,7 @@ func (c *Client) recordResult(ctx context.Context, req *http.Request, resp *http
 		c.cb.RecordFailure()
 		span.SetStatus(codes.Error, lastErr.Error())
 		c.recordMetrics(ctx, req.Method, 0, duration, "error")
-		logger.Error("request failed",
+		logger.ErrorContext(ctx, "request failed",
 			slog.Duration("duration", duration),
 			slog.Any("error", lastErr),
 		)
@@ -327,7 +342,7 @@ func (c *Client) recordResult(ctx context.Context, req *http.Request, resp *http
 	statusCategory := fmt.Sprintf("%dxx", resp.StatusCode/httpStatusCategoryDivisor)
 	c.recordMetrics(ctx, req.Method, resp.StatusCode, duration, statusCategory)
 
-	logger.Debug("request completed",
+	logger.DebugContext(ctx, "request completed",
 		slog.Int("status", resp.StatusCode),
 		slog.Duration("duration", duration),
 	)
@@ -379,6 +394,75 @@ func (c *Client) Delete(ctx context.Context, path string) (*http.Response, error
 	return c.Do(ctx, req)
 }
 
+// Reconfigure applies new timeout, retry and circuit breaker settings to a live
+// client, e.g. after a config reload. Requests already in flight finish with
+// the settings they started with. Transport pool settings are not changed.
+func (c *Client) Reconfigure(timeout time.Duration, retry config.RetryConfig, circuit config.CircuitBreakerConfig) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	c.mu.Lock()
+	c.cfg.Timeout = timeout
+	c.cfg.Retry = retry
+	c.cfg.Circuit = circuit
+	c.http = &http.Client{
+		Timeout:   timeout,
+		Transport: c.http.Transport,
+	}
+	c.mu.Unlock()
+
+	c.cb.SetConfig(CircuitBreakerConfig{
+		MaxFailures:   circuit.MaxFailures,
+		Timeout:       circuit.Timeout,
+		HalfOpenLimit: circuit.HalfOpenLimit,
+	})
+}
+
+// settings returns the current HTTP client and retry configuration.
+func (c *Client) settings() (*http.Client, config.RetryConfig) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.http, c.cfg.Retry
+}
+
+// Name returns the downstream service name.
+// Implements ports.HealthChecker.
+func (c *Client) Name() string {
+	return c.serviceName
+}
+
+// Check reports the downstream service as unhealthy when its circuit breaker
+// is open or, if HealthPath is configured, when the probe does not return 2xx.
+// Implements ports.HealthChecker.
+func (c *Client) Check(ctx context.Context) error {
+	if c.HealthPath() == "" {
+		if c.CircuitState() == StateOpen {
+			return ErrCircuitOpen
+		}
+
+		return nil
+	}
+
+	resp, err := c.Get(ctx, c.HealthPath())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s health check returned status %d", c.serviceName, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// HealthPath returns the path probed by Check, or "" if none is configured.
+func (c *Client) HealthPath() string {
+	return c.cfg.HealthPath
+}
+
 // CircuitState returns the current state of the circuit breaker.
 func (c *Client) CircuitState() State {
 	return c.cb.State()
@@ -414,16 +498,18 @@ func (c *Client) buildURL(path string) string {
 // calculateBackoff returns the backoff duration for the given attempt.
 // Uses exponential backoff with jitter.
 func (c *Client) calculateBackoff(attempt int) time.Duration {
+	_, retry := c.settings()
+
 	// Exponential: initial * multiplier^attempt
-	backoff := float64(c.cfg.Retry.InitialInterval) * math.Pow(c.cfg.Retry.Multiplier, float64(attempt))
+	backoff := float64(retry.InitialInterval) * math.Pow(retry.Multiplier, float64(attempt))
 
 	// Cap at max interval
-	if backoff > float64(c.cfg.Retry.MaxInterval) {
-		backoff = float64(c.cfg.Retry.MaxInterval)
+	if backoff > float64(retry.MaxInterval) {
+		backoff = float64(retry.MaxInterval)
 	}
 
 	// Get jitter factor from config, fallback to default if not set
-	jitterFactor := c.cfg.Retry.JitterFactor
+	jitterFactor := retry.JitterFactor
 	if jitterFactor == 0 {
 		jitterFactor = defaultJitterFactor
 	}
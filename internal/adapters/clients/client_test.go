@@ -1,8 +1,10 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/middleware"
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 )
 
 func defaultConfig() *Config {
@@ -127,6 +130,43 @@ func TestClient_RetryOnServerError(t *testing.T) {
 	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
 }
 
+func TestClient_RetryLogFollowsComponentLevel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := defaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.Retry.MaxAttempts = 2
+
+	client, err := New(cfg)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	levels := logging.NewLevels()
+	logger := logging.NewWithWriter(&logging.Config{Level: "info", Format: "json", Levels: levels}, &buf)
+	ctx := logging.WithContext(context.Background(), logger)
+
+	get := func() {
+		resp, err := client.Get(ctx, "/test")
+		if resp != nil {
+			closeBody(t, resp)
+		}
+
+		require.Error(t, err)
+	}
+
+	get()
+	assert.NotContains(t, buf.String(), "retrying request")
+
+	levels.Set("clients.Client", slog.LevelDebug, 0)
+	get()
+	assert.Contains(t, buf.String(), "retrying request")
+	assert.Contains(t, buf.String(), `"component":"clients.Client"`)
+}
+
 func TestClient_NoRetryOnClientError(t *testing.T) {
 	var attempts int32
 
@@ -470,3 +510,32 @@ func TestClient_AuthFuncCalledOnRetry(t *testing.T) {
 	// AuthFunc should be called: once initially + once on retry
 	assert.Equal(t, int32(2), atomic.LoadInt32(&authCallCount))
 }
+
+func TestClient_Reconfigure(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := defaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.Retry.MaxAttempts = 3
+
+	client, err := New(cfg)
+	require.NoError(t, err)
+
+	retry := cfg.Retry
+	retry.MaxAttempts = 1
+	circuit := config.CircuitBreakerConfig{MaxFailures: 1, Timeout: time.Minute, HalfOpenLimit: 1}
+	client.Reconfigure(2*time.Second, retry, circuit)
+
+	_, err = client.Get(context.Background(), "/test")
+	require.Error(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts), "new retry budget should apply")
+	assert.Equal(t, StateOpen, client.CircuitState(), "new circuit threshold should apply")
+	assert.Equal(t, 2*time.Second, client.http.Timeout)
+}
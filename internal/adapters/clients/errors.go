@@ -14,4 +14,7 @@ var (
 	// ErrMaxRetriesExceeded is returned after all retry attempts have been exhausted.
 	// The original error is wrapped for context.
 	ErrMaxRetriesExceeded = errors.New("max retries exceeded")
+
+	// ErrUnknownService is returned by Registry.Client for a service that is not configured.
+	ErrUnknownService = errors.New("unknown downstream service")
 )
@@ -0,0 +1,118 @@
+package clients
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+// RegistryConfig configures a client Registry.
+type RegistryConfig struct {
+	// Services are the downstream endpoints to build clients for, keyed by
+	// service key (the keys under `services` in config).
+	Services config.ServicesConfig
+
+	// Logger is an optional logger. If nil, a default logger is used.
+	Logger *slog.Logger
+}
+
+// Registry builds and holds one Client per configured downstream service.
+// Adapters look up their client by service key:
+//
+//	httpClient, err := registry.Client("quote")
+type Registry struct {
+	clients map[string]*Client
+	logger  *slog.Logger
+}
+
+// NewRegistry creates a client for every entry in cfg.Services, using each
+// service's own timeout, retry, circuit breaker and transport settings.
+func NewRegistry(cfg RegistryConfig) (*Registry, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	r := &Registry{
+		clients: make(map[string]*Client, len(cfg.Services)),
+		logger:  logger,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(cfg.Services)) {
+		svc := cfg.Services[key]
+
+		client, err := New(&Config{
+			BaseURL:     svc.BaseURL,
+			ServiceName: svc.Name,
+			Timeout:     svc.Timeout,
+			Retry:       svc.Retry,
+			Circuit:     svc.CircuitBreaker,
+			Transport:   svc.Transport,
+			HealthPath:  svc.HealthPath,
+			Logger:      logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating client for service %s: %w", key, err)
+		}
+
+		r.clients[key] = client
+	}
+
+	return r, nil
+}
+
+// Client returns the client for a service key.
+// Returns ErrUnknownService if the service is not configured.
+func (r *Registry) Client(key string) (*Client, error) {
+	client, ok := r.clients[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownService, key)
+	}
+
+	return client, nil
+}
+
+// Keys returns the configured service keys, sorted.
+func (r *Registry) Keys() []string {
+	return slices.Sorted(maps.Keys(r.clients))
+}
+
+// RegisterHealthChecks registers every client as a health checker.
+func (r *Registry) RegisterHealthChecks(health ports.HealthRegistry) error {
+	for _, key := range r.Keys() {
+		if err := health.Register(r.clients[key]); err != nil {
+			return fmt.Errorf("registering health check for service %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// Reconfigure applies reloaded timeout, retry and circuit breaker settings to
+// existing clients. Added or removed services require a restart.
+func (r *Registry) Reconfigure(services config.ServicesConfig) {
+	for key, svc := range services {
+		client, ok := r.clients[key]
+		if !ok {
+			r.logger.Warn("new downstream service configured; restart required",
+				slog.String("service", key),
+			)
+
+			continue
+		}
+
+		client.Reconfigure(svc.Timeout, svc.Retry, svc.CircuitBreaker)
+	}
+
+	for key := range r.clients {
+		if _, ok := services[key]; !ok {
+			r.logger.Warn("downstream service removed from config; restart required",
+				slog.String("service", key),
+			)
+		}
+	}
+}
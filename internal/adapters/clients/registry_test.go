@@ -0,0 +1,136 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+func serviceConfig(baseURL, name string) config.ServiceEndpointConfig {
+	cfg := defaultConfig()
+
+	return config.ServiceEndpointConfig{
+		BaseURL:        baseURL,
+		Name:           name,
+		Timeout:        cfg.Timeout,
+		Retry:          cfg.Retry,
+		CircuitBreaker: cfg.Circuit,
+	}
+}
+
+func TestNewRegistry_BuildsClientPerService(t *testing.T) {
+	payments := serviceConfig("https://payments.example.com", "payments-service")
+	payments.Timeout = 2 * time.Second
+	payments.Retry.MaxAttempts = 1
+
+	registry, err := NewRegistry(RegistryConfig{
+		Services: config.ServicesConfig{
+			"quote":    serviceConfig("https://quotes.example.com", "quote-service"),
+			"payments": payments,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payments", "quote"}, registry.Keys())
+
+	client, err := registry.Client("payments")
+	require.NoError(t, err)
+	assert.Equal(t, "payments-service", client.Name())
+	assert.Equal(t, "https://payments.example.com", client.baseURL)
+
+	httpClient, retry := client.settings()
+	assert.Equal(t, 2*time.Second, httpClient.Timeout)
+	assert.Equal(t, 1, retry.MaxAttempts)
+
+	_, err = registry.Client("unknown")
+	require.ErrorIs(t, err, ErrUnknownService)
+}
+
+func TestNewRegistry_InvalidService(t *testing.T) {
+	_, err := NewRegistry(RegistryConfig{
+		Services: config.ServicesConfig{"quote": serviceConfig("https://quotes.example.com", "")},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service quote")
+}
+
+func TestRegistry_RegisterHealthChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	healthy := serviceConfig(server.URL, "healthy-service")
+	healthy.HealthPath = "/ping"
+
+	unhealthy := serviceConfig(server.URL, "unhealthy-service")
+	unhealthy.HealthPath = "/healthz"
+	unhealthy.Retry.MaxAttempts = 1
+
+	registry, err := NewRegistry(RegistryConfig{
+		Services: config.ServicesConfig{
+			"healthy":   healthy,
+			"unhealthy": unhealthy,
+			"passive":   serviceConfig(server.URL, "passive-service"),
+		},
+	})
+	require.NoError(t, err)
+
+	health := ports.NewHealthRegistry()
+	require.NoError(t, registry.RegisterHealthChecks(health))
+
+	result := health.CheckAll(context.Background())
+	assert.Equal(t, ports.HealthStatusHealthy, result.Checks["healthy-service"].Status)
+	assert.Equal(t, ports.HealthStatusHealthy, result.Checks["passive-service"].Status)
+	assert.Equal(t, ports.HealthStatusUnhealthy, result.Checks["unhealthy-service"].Status)
+
+	// Registering twice reports the duplicate
+	require.ErrorIs(t, registry.RegisterHealthChecks(health), ports.ErrDuplicateChecker)
+}
+
+func TestClient_Check_ReportsOpenCircuit(t *testing.T) {
+	client, err := New(defaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, client.Check(context.Background()))
+
+	for range defaultConfig().Circuit.MaxFailures {
+		client.cb.RecordFailure()
+	}
+
+	require.ErrorIs(t, client.Check(context.Background()), ErrCircuitOpen)
+}
+
+func TestRegistry_Reconfigure(t *testing.T) {
+	quote := serviceConfig("https://quotes.example.com", "quote-service")
+
+	registry, err := NewRegistry(RegistryConfig{Services: config.ServicesConfig{"quote": quote}})
+	require.NoError(t, err)
+
+	quote.Timeout = 3 * time.Second
+	quote.Retry.MaxAttempts = 5
+	registry.Reconfigure(config.ServicesConfig{
+		"quote": quote,
+		"new":   serviceConfig("https://new.example.com", "new-service"),
+	})
+
+	client, err := registry.Client("quote")
+	require.NoError(t, err)
+
+	httpClient, retry := client.settings()
+	assert.Equal(t, 3*time.Second, httpClient.Timeout)
+	assert.Equal(t, 5, retry.MaxAttempts)
+
+	_, err = registry.Client("new")
+	require.ErrorIs(t, err, ErrUnknownService, "new services require a restart")
+}
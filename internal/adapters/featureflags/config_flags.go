@@ -0,0 +1,142 @@
+// Package featureflags provides adapters implementing ports.FeatureFlags.
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+// ErrFlagNotFound is returned by GetJSON when the flag is not configured.
+var ErrFlagNotFound = errors.New("feature flag not found")
+
+// Compile-time check that ConfigFlags implements ports.FeatureFlags.
+var _ ports.FeatureFlags = (*ConfigFlags)(nil)
+
+// ConfigFlags implements ports.FeatureFlags using the `features` section of
+// the service configuration. Nested keys are addressed with dots
+// (e.g. "checkout.new-flow"). Flags can be replaced at runtime with Update,
+// typically from a config.Watcher subscriber.
+//
+// Targeting rules are not supported; the context is ignored.
+type ConfigFlags struct {
+	flags atomic.Pointer[map[string]any]
+}
+
+// NewConfigFlags creates a feature flag adapter backed by the given values.
+func NewConfigFlags(flags map[string]any) *ConfigFlags {
+	f := &ConfigFlags{}
+	f.Update(flags)
+
+	return f
+}
+
+// Update atomically replaces all flag values.
+func (f *ConfigFlags) Update(flags map[string]any) {
+	if flags == nil {
+		flags = map[string]any{}
+	}
+
+	f.flags.Store(&flags)
+}
+
+// IsEnabled checks if a boolean feature flag is enabled.
+// String values such as "true" or "1" are accepted (e.g. from env vars).
+func (f *ConfigFlags) IsEnabled(_ context.Context, flag string, defaultValue bool) bool {
+	switch v := f.lookup(flag).(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+
+	return defaultValue
+}
+
+// GetString retrieves a string feature flag value.
+func (f *ConfigFlags) GetString(_ context.Context, flag, defaultValue string) string {
+	if v, ok := f.lookup(flag).(string); ok {
+		return v
+	}
+
+	return defaultValue
+}
+
+// GetInt retrieves an integer feature flag value.
+func (f *ConfigFlags) GetInt(_ context.Context, flag string, defaultValue int) int {
+	switch v := f.lookup(flag).(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+
+	return defaultValue
+}
+
+// GetFloat retrieves a float feature flag value.
+func (f *ConfigFlags) GetFloat(_ context.Context, flag string, defaultValue float64) float64 {
+	switch v := f.lookup(flag).(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if fl, err := strconv.ParseFloat(v, 64); err == nil {
+			return fl
+		}
+	}
+
+	return defaultValue
+}
+
+// GetJSON retrieves a structured feature flag value and unmarshals it into target.
+func (f *ConfigFlags) GetJSON(_ context.Context, flag string, target any) error {
+	v := f.lookup(flag)
+	if v == nil {
+		return fmt.Errorf("%w: %s", ErrFlagNotFound, flag)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding flag %s: %w", flag, err)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("decoding flag %s: %w", flag, err)
+	}
+
+	return nil
+}
+
+// lookup walks the dotted flag path and returns its value, or nil if absent.
+func (f *ConfigFlags) lookup(flag string) any {
+	var current any = *f.flags.Load()
+
+	for part := range strings.SplitSeq(flag, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = m[part]
+	}
+
+	return current
+}
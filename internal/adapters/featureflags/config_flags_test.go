@@ -0,0 +1,67 @@
+package featureflags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFlags_IsEnabled(t *testing.T) {
+	ctx := context.Background()
+	flags := NewConfigFlags(map[string]any{
+		"new-checkout": true,
+		"from-env":     "true",
+		"checkout":     map[string]any{"v2": false},
+	})
+
+	assert.True(t, flags.IsEnabled(ctx, "new-checkout", false))
+	assert.True(t, flags.IsEnabled(ctx, "from-env", false))
+	assert.False(t, flags.IsEnabled(ctx, "checkout.v2", true))
+	assert.True(t, flags.IsEnabled(ctx, "missing", true), "missing flag should use default")
+}
+
+func TestConfigFlags_TypedValues(t *testing.T) {
+	ctx := context.Background()
+	flags := NewConfigFlags(map[string]any{
+		"variant": "blue",
+		"limit":   10,
+		"ratio":   0.25,
+		"rollout": "42",
+	})
+
+	assert.Equal(t, "blue", flags.GetString(ctx, "variant", "red"))
+	assert.Equal(t, "red", flags.GetString(ctx, "limit", "red"), "wrong type should use default")
+	assert.Equal(t, 10, flags.GetInt(ctx, "limit", 0))
+	assert.Equal(t, 42, flags.GetInt(ctx, "rollout", 0))
+	assert.InEpsilon(t, 0.25, flags.GetFloat(ctx, "ratio", 1), 0.0001)
+	assert.InEpsilon(t, 10.0, flags.GetFloat(ctx, "limit", 1), 0.0001)
+}
+
+func TestConfigFlags_GetJSON(t *testing.T) {
+	ctx := context.Background()
+	flags := NewConfigFlags(map[string]any{
+		"checkout": map[string]any{"max_items": 5, "enabled": true},
+	})
+
+	var target struct {
+		MaxItems int  `json:"max_items"`
+		Enabled  bool `json:"enabled"`
+	}
+	require.NoError(t, flags.GetJSON(ctx, "checkout", &target))
+	assert.Equal(t, 5, target.MaxItems)
+	assert.True(t, target.Enabled)
+
+	err := flags.GetJSON(ctx, "missing", &target)
+	require.ErrorIs(t, err, ErrFlagNotFound)
+}
+
+func TestConfigFlags_Update(t *testing.T) {
+	ctx := context.Background()
+	flags := NewConfigFlags(nil)
+	assert.False(t, flags.IsEnabled(ctx, "new-checkout", false))
+
+	flags.Update(map[string]any{"new-checkout": true})
+	assert.True(t, flags.IsEnabled(ctx, "new-checkout", false))
+}
@@ -81,9 +81,8 @@ func RespondWithError(c *gin.Context, err error) {
 	// Log internal errors with full details
 	if status == http.StatusInternalServerError {
 		logger := logging.FromContext(c.Request.Context())
-		logger.Error("internal error",
+		logger.ErrorContext(c.Request.Context(), "internal error",
 			"error", err.Error(),
-			"trace_id", errResp.TraceID,
 		)
 	}
 
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsamuelsen/go-service-template/internal/adapters/http/dto"
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+// ConfigProvider supplies the active configuration for the /-/config endpoint.
+// *config.Watcher implements it.
+type ConfigProvider interface {
+	Current() *config.Config
+}
+
+// ConfigHandler serves the effective configuration.
+type ConfigHandler struct {
+	provider ConfigProvider
+}
+
+// NewConfigHandler creates a handler serving the configuration returned by provider.
+func NewConfigHandler(provider ConfigProvider) *ConfigHandler {
+	return &ConfigHandler{provider: provider}
+}
+
+// configResponse is the response structure for /-/config endpoint.
+type configResponse struct {
+	Hash   string         `json:"hash"`
+	Config map[string]any `json:"config"`
+}
+
+// Get handles GET /-/config.
+// Returns the effective merged configuration, redacted like log output:
+// secret fields and resolved secret references, the built-in secret field
+// names (logging.DefaultRedactOptions) and the log.redact policy. It also
+// returns a SHA-256 hash of the redacted document. Replicas running the same
+// configuration report the same hash, so comparing hashes across pods
+// exposes drift.
+func (h *ConfigHandler) Get(c *gin.Context) {
+	cfg := h.provider.Current()
+
+	policy, err := redactPolicy(&cfg.Log.Redact)
+	if err != nil {
+		dto.HandleError(c, err)
+		return
+	}
+
+	replace := logging.NewReplaceAttr(append(
+		logging.SecretRedactOptions(cfg.SecretValues()),
+		logging.RedactOptions(policy)...,
+	)...)
+
+	tree, err := cfg.RedactedTree(replace)
+	if err != nil {
+		dto.HandleError(c, err)
+		return
+	}
+
+	// encoding/json sorts map keys, so equal configs hash equally
+	doc, err := json.Marshal(tree)
+	if err != nil {
+		dto.HandleError(c, err)
+		return
+	}
+
+	sum := sha256.Sum256(doc)
+
+	c.JSON(http.StatusOK, configResponse{
+		Hash:   "sha256:" + hex.EncodeToString(sum[:]),
+		Config: tree,
+	})
+}
+
+// RegisterConfigRoutes registers the config route on the given router group:
+//   - GET /-/config - Effective configuration, redacted
+func (h *ConfigHandler) RegisterConfigRoutes(rg *gin.RouterGroup) {
+	rg.GET("/config", h.Get)
+}
+
+// redactPolicy converts the log.redact settings to a logging policy.
+func redactPolicy(r *config.LogRedactConfig) (logging.RedactConfig, error) {
+	patterns, err := r.Regexps()
+	if err != nil {
+		return logging.RedactConfig{}, err
+	}
+
+	return logging.RedactConfig{
+		Fields:    r.Fields,
+		Patterns:  patterns,
+		Detectors: r.Detectors,
+		Style:     logging.RedactStyle(r.Style),
+		HashKey:   r.HashKey,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+)
+
+type staticConfig struct {
+	cfg *config.Config
+}
+
+func (s staticConfig) Current() *config.Config { return s.cfg }
+
+// loadTestConfig loads the defaults plus secrets set via the environment and
+// the given configs/base.yaml, if any.
+func loadTestConfig(t *testing.T, baseYAML string) *config.Config {
+	t.Helper()
+
+	t.Chdir(t.TempDir())
+	t.Setenv("APP_FEATURES_API_KEY", "k-123")
+	t.Setenv("QUOTE_AUDIENCE", "s3cr3t-audience")
+	t.Setenv("APP_AUTH_AUDIENCE", "env:QUOTE_AUDIENCE")
+	t.Setenv("APP_TELEMETRY_BEARER_TOKEN", "tok123")
+	t.Setenv("APP_TELEMETRY_HEADERS_TENANT", "hdr-456")
+
+	if baseYAML != "" {
+		require.NoError(t, os.Mkdir("configs", 0o750))
+		require.NoError(t, os.WriteFile("configs/base.yaml", []byte(baseYAML), 0o600))
+	}
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	return cfg
+}
+
+// getConfig serves GET /-/config for cfg and decodes the response.
+func getConfig(t *testing.T, cfg *config.Config) (configResponse, string) {
+	t.Helper()
+
+	router := gin.New()
+	NewConfigHandler(staticConfig{cfg: cfg}).RegisterConfigRoutes(router.Group("/-"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/config", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp configResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	return resp, w.Body.String()
+}
+
+func TestConfigHandler_Get(t *testing.T) {
+	cfg := loadTestConfig(t, "")
+
+	resp, body := getConfig(t, cfg)
+
+	assert.NotContains(t, body, "s3cr3t-audience")
+	assert.NotContains(t, body, "k-123")
+	assert.NotContains(t, body, "tok123")
+	assert.NotContains(t, body, "hdr-456")
+
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, resp.Hash)
+	assert.Equal(t, "[REDACTED]", resp.Config["auth"].(map[string]any)["audience"])
+	assert.Equal(t, "[REDACTED]", resp.Config["features"].(map[string]any)["api_key"])
+	assert.InDelta(t, 8080, resp.Config["server"].(map[string]any)["port"], 0)
+
+	telemetry := resp.Config["telemetry"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", telemetry["bearer_token"])
+	assert.Equal(t, map[string]any{"tenant": "[REDACTED]"}, telemetry["headers"])
+
+	again, _ := getConfig(t, cfg)
+	assert.Equal(t, resp.Hash, again.Hash, "hash should be stable for the same config")
+}
+
+func TestConfigHandler_Get_RedactPolicy(t *testing.T) {
+	cfg := loadTestConfig(t, `
+server:
+  host: internal-host-42.example.com
+services:
+  quote:
+    base_url: https://internal-quotes.example.com
+log:
+  redact:
+    fields: [host]
+    patterns: ['internal-[a-z0-9-]+']
+`)
+
+	resp, body := getConfig(t, cfg)
+
+	assert.NotContains(t, body, "internal-host-42")
+	assert.Equal(t, "[REDACTED]", resp.Config["server"].(map[string]any)["host"])
+	assert.Equal(t, "https://[REDACTED].example.com",
+		resp.Config["services"].(map[string]any)["quote"].(map[string]any)["base_url"])
+}
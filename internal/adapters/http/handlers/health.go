@@ -100,7 +100,9 @@ func (h *HealthHandler) BuildInfoHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, h.buildInfo)
 }
 
-// MetricsHandler returns an http.Handler for Prometheus metrics.
+// MetricsHandler returns an http.Handler for Prometheus metrics from the
+// default registry, which includes the OpenTelemetry instruments when the
+// telemetry Prometheus exporter is registered there.
 // Use this with gin.WrapH() to register it as a route.
 func MetricsHandler() http.Handler {
 	return promhttp.Handler()
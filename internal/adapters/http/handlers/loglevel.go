@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsamuelsen/go-service-template/internal/adapters/http/dto"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+const (
+	// DefaultLogLevelTTL is how long an override lasts when the request gives no ttl.
+	DefaultLogLevelTTL = 15 * time.Minute
+
+	// MaxLogLevelTTL caps override lifetimes so verbose logging is never left on indefinitely.
+	MaxLogLevelTTL = 24 * time.Hour
+)
+
+// LogLevelHandler handles the runtime log level admin endpoints.
+type LogLevelHandler struct {
+	levels *logging.Levels
+	global slog.Leveler
+}
+
+// NewLogLevelHandler creates a handler that manages overrides in levels.
+// global is the service-wide level, reported for reference.
+func NewLogLevelHandler(levels *logging.Levels, global slog.Leveler) *LogLevelHandler {
+	return &LogLevelHandler{
+		levels: levels,
+		global: global,
+	}
+}
+
+// logLevelsResponse is the response structure for GET /-/log-levels.
+type logLevelsResponse struct {
+	Level     string             `json:"level"`
+	Overrides []logLevelOverride `json:"overrides"`
+}
+
+// logLevelOverride is a single per-component override.
+type logLevelOverride struct {
+	Component string    `json:"component"`
+	Level     string    `json:"level"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// setLogLevelRequest is the request body for PUT /-/log-levels/:component.
+type setLogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+	TTL   string `json:"ttl"`
+}
+
+// List handles GET /-/log-levels.
+// Returns the global level and every active per-component override.
+func (h *LogLevelHandler) List(c *gin.Context) {
+	overrides := h.levels.Overrides()
+
+	resp := logLevelsResponse{
+		Level:     logging.LevelName(h.global.Level()),
+		Overrides: make([]logLevelOverride, 0, len(overrides)),
+	}
+
+	for _, o := range overrides {
+		resp.Overrides = append(resp.Overrides, logLevelOverride{
+			Component: o.Key,
+			Level:     logging.LevelName(o.Level),
+			ExpiresAt: o.ExpiresAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Set handles PUT /-/log-levels/:component.
+// Overrides the level for loggers whose component or downstream attribute
+// matches :component, until the ttl (default 15m, max 24h) elapses.
+func (h *LogLevelHandler) Set(c *gin.Context) {
+	component := c.Param("component")
+
+	var req setLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, "request body must be JSON with a level field")
+		return
+	}
+
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	ttl, err := parseLogLevelTTL(req.TTL)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	expiresAt := h.levels.Set(component, level, ttl)
+
+	logging.FromContext(c.Request.Context()).InfoContext(c.Request.Context(), "log level override set",
+		slog.String("target", component),
+		slog.String("level", logging.LevelName(level)),
+		slog.Duration("ttl", ttl),
+	)
+
+	c.JSON(http.StatusOK, logLevelOverride{
+		Component: component,
+		Level:     logging.LevelName(level),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// Reset handles DELETE /-/log-levels/:component.
+// Removes the override so the component reverts to the global level.
+func (h *LogLevelHandler) Reset(c *gin.Context) {
+	component := c.Param("component")
+
+	if !h.levels.Reset(component) {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse(
+			dto.ErrorCodeNotFound,
+			"no log level override for "+component,
+		).WithTraceID(dto.GetTraceID(c)))
+
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).InfoContext(c.Request.Context(), "log level override removed", slog.String("target", component))
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegisterLogLevelRoutes registers the log level routes on the given router group:
+//   - GET /-/log-levels - Global level and active overrides
+//   - PUT /-/log-levels/:component - Override a component's level for a ttl
+//   - DELETE /-/log-levels/:component - Remove an override
+func (h *LogLevelHandler) RegisterLogLevelRoutes(rg *gin.RouterGroup) {
+	rg.GET("/log-levels", h.List)
+	rg.PUT("/log-levels/:component", h.Set)
+	rg.DELETE("/log-levels/:component", h.Reset)
+}
+
+// parseLogLevelTTL parses an override ttl, applying the default and cap.
+func parseLogLevelTTL(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultLogLevelTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(s)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("ttl must be a positive duration such as 10m, got %q", s)
+	}
+
+	if ttl > MaxLogLevelTTL {
+		return 0, fmt.Errorf("ttl must not exceed %s", MaxLogLevelTTL)
+	}
+
+	return ttl, nil
+}
+
+// badRequest responds with a 400 error envelope.
+func badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, dto.NewErrorResponse(
+		dto.ErrorCodeBadRequest,
+		message,
+	).WithTraceID(dto.GetTraceID(c)))
+}
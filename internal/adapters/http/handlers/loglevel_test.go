@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+func newLogLevelRouter(levels *logging.Levels) *gin.Engine {
+	router := gin.New()
+	NewLogLevelHandler(levels, slog.LevelInfo).RegisterLogLevelRoutes(router.Group("/-"))
+
+	return router
+}
+
+func serveLogLevels(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestLogLevelHandler_SetListReset(t *testing.T) {
+	levels := logging.NewLevels()
+	router := newLogLevelRouter(levels)
+
+	w := serveLogLevels(router, http.MethodPut, "/-/log-levels/clients.Client", `{"level":"trace","ttl":"10m"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var set logLevelOverride
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &set))
+	assert.Equal(t, "clients.Client", set.Component)
+	assert.Equal(t, "trace", set.Level)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), set.ExpiresAt, time.Minute)
+
+	w = serveLogLevels(router, http.MethodGet, "/-/log-levels", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list logLevelsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, "info", list.Level)
+	require.Len(t, list.Overrides, 1)
+	assert.Equal(t, "clients.Client", list.Overrides[0].Component)
+
+	w = serveLogLevels(router, http.MethodDelete, "/-/log-levels/clients.Client", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, levels.Overrides())
+
+	w = serveLogLevels(router, http.MethodDelete, "/-/log-levels/clients.Client", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestLogLevelHandler_SetDefaultsTTL(t *testing.T) {
+	levels := logging.NewLevels()
+	router := newLogLevelRouter(levels)
+
+	w := serveLogLevels(router, http.MethodPut, "/-/log-levels/quote", `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	overrides := levels.Overrides()
+	require.Len(t, overrides, 1)
+	assert.WithinDuration(t, time.Now().Add(DefaultLogLevelTTL), overrides[0].ExpiresAt, time.Minute)
+}
+
+func TestLogLevelHandler_SetRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "missing level", body: `{}`, want: "level field"},
+		{name: "unknown level", body: `{"level":"verbose"}`, want: "unknown log level"},
+		{name: "bad ttl", body: `{"level":"debug","ttl":"soon"}`, want: "positive duration"},
+		{name: "ttl too long", body: `{"level":"debug","ttl":"48h"}`, want: "must not exceed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := logging.NewLevels()
+			w := serveLogLevels(newLogLevelRouter(levels), http.MethodPut, "/-/log-levels/quote", tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.want)
+			assert.Empty(t, levels.Overrides())
+		})
+	}
+}
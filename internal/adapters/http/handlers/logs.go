@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+// DefaultLogsLimit is how many records GET /-/logs returns when the request gives no limit.
+const DefaultLogsLimit = 100
+
+// LogsHandler serves recent log records from the in-memory buffer.
+type LogsHandler struct {
+	buffer *logging.RingBuffer
+}
+
+// NewLogsHandler creates a handler that queries buffer.
+func NewLogsHandler(buffer *logging.RingBuffer) *LogsHandler {
+	return &LogsHandler{buffer: buffer}
+}
+
+// logsResponse is the response structure for GET /-/logs.
+type logsResponse struct {
+	Count   int             `json:"count"`
+	Records []logging.Entry `json:"records"`
+}
+
+// Query handles GET /-/logs.
+// Returns the most recent buffered records, oldest first, filtered by the
+// level (minimum), request_id, correlation_id, since and until (RFC 3339)
+// query parameters. limit defaults to 100 and is capped at the buffer size.
+func (h *LogsHandler) Query(c *gin.Context) {
+	filter, err := h.parseFilter(c)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	records := h.buffer.Query(filter)
+
+	c.JSON(http.StatusOK, logsResponse{
+		Count:   len(records),
+		Records: records,
+	})
+}
+
+// RegisterLogsRoutes registers the log buffer route on the given router group:
+//   - GET /-/logs - Recent log records, filtered by query parameters
+func (h *LogsHandler) RegisterLogsRoutes(rg *gin.RouterGroup) {
+	rg.GET("/logs", h.Query)
+}
+
+// parseFilter builds the buffer filter from the query parameters.
+func (h *LogsHandler) parseFilter(c *gin.Context) (logging.EntryFilter, error) {
+	filter := logging.EntryFilter{
+		RequestID:     c.Query("request_id"),
+		CorrelationID: c.Query("correlation_id"),
+		Limit:         min(DefaultLogsLimit, h.buffer.Size()),
+	}
+
+	if s := c.Query("level"); s != "" {
+		level, err := logging.ParseLevel(s)
+		if err != nil {
+			return filter, err
+		}
+
+		filter.MinLevel = level
+	}
+
+	var err error
+
+	if filter.Since, err = parseLogsTime("since", c.Query("since")); err != nil {
+		return filter, err
+	}
+
+	if filter.Until, err = parseLogsTime("until", c.Query("until")); err != nil {
+		return filter, err
+	}
+
+	if s := c.Query("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("limit must be a positive integer, got %q", s)
+		}
+
+		filter.Limit = min(limit, h.buffer.Size())
+	}
+
+	return filter, nil
+}
+
+// parseLogsTime parses an optional RFC 3339 time query parameter.
+func parseLogsTime(name, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC 3339 time such as 2024-01-15T10:30:00Z, got %q", name, s)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+func newLogsRouter(t *testing.T) (*gin.Engine, *slog.Logger) {
+	t.Helper()
+
+	buffer := logging.NewRingBuffer(50)
+	logger := logging.NewWithWriter(&logging.Config{Level: "debug", Format: "json", Buffer: buffer}, io.Discard)
+
+	router := gin.New()
+	NewLogsHandler(buffer).RegisterLogsRoutes(router.Group("/-"))
+
+	return router, logger
+}
+
+func getLogs(t *testing.T, router *gin.Engine, query string) (int, logsResponse) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/logs"+query, nil))
+
+	var resp logsResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+
+	return w.Code, resp
+}
+
+func TestLogsHandler_Query(t *testing.T) {
+	router, logger := newLogsRouter(t)
+
+	logger.With(slog.String(logging.RequestIDKey, "r1")).Info("first")
+	logger.With(slog.String(logging.RequestIDKey, "r2"), slog.String(logging.CorrelationIDKey, "c9")).Warn("second")
+	logger.Debug("third")
+
+	code, resp := getLogs(t, router, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 3, resp.Count)
+
+	_, resp = getLogs(t, router, "?level=warn")
+	require.Len(t, resp.Records, 1)
+	assert.Equal(t, "second", resp.Records[0].Message)
+	assert.Equal(t, "warn", resp.Records[0].Level)
+
+	_, resp = getLogs(t, router, "?request_id=r1")
+	require.Len(t, resp.Records, 1)
+	assert.Equal(t, "first", resp.Records[0].Message)
+
+	_, resp = getLogs(t, router, "?correlation_id=c9")
+	assert.Len(t, resp.Records, 1)
+
+	_, resp = getLogs(t, router, "?limit=1")
+	require.Len(t, resp.Records, 1)
+	assert.Equal(t, "third", resp.Records[0].Message)
+
+	_, resp = getLogs(t, router, "?since=2999-01-01T00:00:00Z")
+	assert.Empty(t, resp.Records)
+}
+
+func TestLogsHandler_RejectsInvalidQuery(t *testing.T) {
+	router, _ := newLogsRouter(t)
+
+	for _, query := range []string{"?level=verbose", "?since=yesterday", "?until=1", "?limit=0"} {
+		code, _ := getLogs(t, router, query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
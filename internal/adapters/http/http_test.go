@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,17 +9,24 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/dto"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/handlers"
 	"github.com/jsamuelsen/go-service-template/internal/domain"
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 )
 
 func init() {
@@ -669,6 +677,207 @@ func TestSetupRouter(t *testing.T) {
 	assert.True(t, hasHealthRoute, "health routes should be registered")
 }
 
+// TestSetupRouterContinuesIncomingTrace tests that the default chain starts a
+// server span from the traceparent header and enriches it.
+func TestSetupRouterContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	engine := gin.New()
+	SetupRouter(engine, RouterConfig{
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		AuthConfig:    &config.AuthConfig{},
+		AppConfig:     &config.AppConfig{Name: "test-service"},
+		HealthHandler: handlers.NewHealthHandler(nil, handlers.BuildInfo{}),
+	})
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/-/live", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	req.Header.Set("X-Request-ID", "req-1")
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, traceID, w.Header().Get("X-Trace-ID"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, parentSpanID, spans[0].Parent().SpanID().String())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("request_id", "req-1"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("http.route", "/-/live"))
+}
+
+// useDefaultLogger installs a JSON logger built from cfg as the default
+// logger, the way main does, and returns the buffer it writes to.
+func useDefaultLogger(t *testing.T, cfg *logging.Config) *bytes.Buffer {
+	t.Helper()
+
+	prev := logging.FromContext(context.Background())
+	t.Cleanup(func() { logging.SetDefault(prev) })
+
+	var buf bytes.Buffer
+
+	cfg.Format = "json"
+	logging.SetDefault(logging.NewWithWriter(cfg, &buf))
+
+	return &buf
+}
+
+// logRecords decodes the JSON log lines in buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+
+	for line := range strings.Lines(buf.String()) {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// findRecord returns the first record with the given message, or nil.
+func findRecord(records []map[string]any, msg string) map[string]any {
+	for _, record := range records {
+		if record["msg"] == msg {
+			return record
+		}
+	}
+
+	return nil
+}
+
+// TestSetupRouterRequestLogsUseDefaultLogger tests that request logs go
+// through the configured default logger with request_id and trace_id attrs.
+func TestSetupRouterRequestLogsUseDefaultLogger(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	buf := useDefaultLogger(t, &logging.Config{Level: "info"})
+
+	engine := gin.New()
+	SetupRouter(engine, RouterConfig{
+		Logger:     logging.FromContext(context.Background()),
+		AuthConfig: &config.AuthConfig{},
+		AppConfig:  &config.AppConfig{Name: "test-service"},
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	req.Header.Set("X-Request-ID", "req-1")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	record := findRecord(logRecords(t, buf), "request completed")
+	require.NotNil(t, record, buf.String())
+	assert.Equal(t, traceID, record["trace_id"])
+	assert.Equal(t, "req-1", record["request_id"])
+}
+
+// TestSetupRouterRequestDebugHeader tests that the debug log header lets
+// debug records from an allow-listed caller's request reach the output.
+func TestSetupRouterRequestDebugHeader(t *testing.T) {
+	buf := useDefaultLogger(t, &logging.Config{Level: "info"})
+
+	engine := gin.New()
+	SetupRouter(engine, RouterConfig{
+		Logger:     logging.FromContext(context.Background()),
+		AuthConfig: &config.AuthConfig{},
+		AppConfig:  &config.AppConfig{Name: "test-service"},
+		RequestDebug: &config.LogRequestDebugConfig{
+			Enabled:      true,
+			Header:       "X-Debug-Log",
+			AllowedRoles: []string{AdminRole},
+		},
+	})
+	engine.GET("/api/v1/debug", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).DebugContext(ctx, "handler debug")
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/debug", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Nil(t, findRecord(logRecords(t, buf), "handler debug"), "debug record without the header")
+
+	buf.Reset()
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/debug", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	req.Header.Set("X-Debug-Log", "true")
+	req.Header.Set("X-Request-ID", "req-debug")
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	record := findRecord(logRecords(t, buf), "handler debug")
+	require.NotNil(t, record, buf.String())
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "req-debug", record["request_id"])
+}
+
+// TestSetupRouterLogsByRequestID tests that a request's records reach the
+// log buffer and can be read back from /-/logs by request ID.
+func TestSetupRouterLogsByRequestID(t *testing.T) {
+	buffer := logging.NewRingBuffer(50)
+	useDefaultLogger(t, &logging.Config{Level: "info", Buffer: buffer})
+
+	engine := gin.New()
+	SetupRouter(engine, RouterConfig{
+		Logger:      logging.FromContext(context.Background()),
+		AuthConfig:  &config.AuthConfig{},
+		AppConfig:   &config.AppConfig{Name: "test-service"},
+		LogsHandler: handlers.NewLogsHandler(buffer),
+	})
+
+	for _, id := range []string{"req-1", "req-2"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/missing", nil)
+		req.Header.Set("X-Request-ID", id)
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/-/logs?request_id=req-2", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	engine.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Count   int             `json:"count"`
+		Records []logging.Entry `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	require.Equal(t, 2, resp.Count, w.Body.String())
+	assert.Equal(t, "request started", resp.Records[0].Message)
+	assert.Equal(t, "request completed", resp.Records[1].Message)
+
+	for _, record := range resp.Records {
+		assert.Equal(t, "req-2", record.Attrs["request_id"])
+	}
+}
+
 // TestSetupRouterWithoutTimeout tests router setup with zero timeout.
 func TestSetupRouterWithoutTimeout(t *testing.T) {
 	engine := gin.New()
@@ -720,6 +929,87 @@ func TestSetupRouterWithNilHealthHandler(t *testing.T) {
 	})
 }
 
+// TestSetupRouterLogLevelsRequireAdminRole tests that the log level endpoints are admin-only.
+func TestSetupRouterLogLevelsRequireAdminRole(t *testing.T) {
+	engine := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	SetupRouter(engine, RouterConfig{
+		Logger:          logger,
+		AuthConfig:      &config.AuthConfig{},
+		AppConfig:       &config.AppConfig{Name: "test-service"},
+		LogLevelHandler: handlers.NewLogLevelHandler(logging.NewLevels(), slog.LevelInfo),
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/log-levels", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/-/log-levels", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestSetupRouterLogsRequireAdminRole tests that the log buffer endpoint is admin-only.
+func TestSetupRouterLogsRequireAdminRole(t *testing.T) {
+	engine := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	SetupRouter(engine, RouterConfig{
+		Logger:      logger,
+		AuthConfig:  &config.AuthConfig{},
+		AppConfig:   &config.AppConfig{Name: "test-service"},
+		LogsHandler: handlers.NewLogsHandler(logging.NewRingBuffer(10)),
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/logs", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/-/logs", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestSetupRouterConfigRequiresAdminRole tests that the config endpoint is admin-only.
+func TestSetupRouterConfigRequiresAdminRole(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	engine := gin.New()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	SetupRouter(engine, RouterConfig{
+		Logger:        logger,
+		AuthConfig:    &config.AuthConfig{},
+		AppConfig:     &config.AppConfig{Name: "test-service"},
+		HealthHandler: handlers.NewHealthHandler(nil, handlers.BuildInfo{}),
+		ConfigHandler: handlers.NewConfigHandler(staticConfig{cfg: cfg}),
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/-/config", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/-/config", nil)
+	req.Header.Set("X-User-Roles", AdminRole)
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+type staticConfig struct {
+	cfg *config.Config
+}
+
+func (s staticConfig) Current() *config.Config { return s.cfg }
+
 // TestMaxBodySizeMiddleware tests the max request body size middleware.
 func TestMaxBodySizeMiddleware(t *testing.T) {
 	cfg := &config.ServerConfig{
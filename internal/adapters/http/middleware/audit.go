@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+// Audit returns middleware that makes logger the request's ports.AuditLogger.
+// The authorization middleware (RequireRole, RequireScopes, ...) then records
+// its decision and the request outcome, and RequestContext.Commit records
+// the staged actions it commits. Events recorded during the request are
+// stamped with the caller's subject, the route, and the request and
+// correlation IDs.
+//
+// Must run after RequestID and CorrelationID so their IDs are in the context.
+func Audit(authCfg *config.AuthConfig, logger ports.AuditLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		claims := getOrExtractClaims(c, authCfg)
+
+		scoped := &requestAuditLogger{
+			next:          logger,
+			subject:       claims.Subject,
+			resource:      auditResource(c),
+			requestID:     RequestIDFromContext(ctx),
+			correlationID: CorrelationIDFromContext(ctx),
+		}
+
+		c.Request = c.Request.WithContext(ports.WithAuditLogger(ctx, scoped))
+		c.Next()
+	}
+}
+
+// requestAuditLogger fills in request fields the event leaves empty and
+// logs write failures, so callers can ignore the returned error.
+type requestAuditLogger struct {
+	next          ports.AuditLogger
+	subject       string
+	resource      string
+	requestID     string
+	correlationID string
+}
+
+// Record fills in the request fields and records the event.
+func (l *requestAuditLogger) Record(ctx context.Context, event ports.AuditEvent) error { //nolint:gocritic // ports.AuditLogger interface takes value
+	if event.Subject == "" {
+		event.Subject = l.subject
+	}
+
+	if event.Resource == "" {
+		event.Resource = l.resource
+	}
+
+	if event.RequestID == "" {
+		event.RequestID = l.requestID
+	}
+
+	if event.CorrelationID == "" {
+		event.CorrelationID = l.correlationID
+	}
+
+	if err := l.next.Record(ctx, event); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to record audit event",
+			slog.String("action", event.Action),
+			slog.String("outcome", string(event.Outcome)),
+			slog.Any("error", err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// authorize runs the rest of the chain for an allowed request, then records
+// the allow decision with the outcome of the handler.
+func authorize(c *gin.Context, claims *Claims, requirement map[string]any) {
+	c.Next()
+
+	if ports.GetAuditLogger(c.Request.Context()) == nil {
+		return
+	}
+
+	status := c.Writer.Status()
+	outcome, reason := ports.AuditOutcomeSuccess, ""
+
+	if status >= http.StatusBadRequest {
+		outcome, reason = ports.AuditOutcomeFailure, http.StatusText(status)
+	}
+
+	details := maps.Clone(requirement)
+	details["status"] = status
+
+	recordAuthorization(c, claims, ports.AuditDecisionAllow, outcome, reason, details)
+}
+
+// forbid records the deny decision and aborts with a 403 Forbidden response.
+func forbid(c *gin.Context, claims *Claims, message string, requirement map[string]any) {
+	recordAuthorization(c, claims, ports.AuditDecisionDeny, ports.AuditOutcomeDenied, message, requirement)
+	abortWithForbidden(c, message)
+}
+
+// recordAuthorization records an authorization decision if auditing is on.
+func recordAuthorization(
+	c *gin.Context,
+	claims *Claims,
+	decision string,
+	outcome ports.AuditOutcome,
+	reason string,
+	details map[string]any,
+) {
+	ctx := c.Request.Context()
+
+	logger := ports.GetAuditLogger(ctx)
+	if logger == nil {
+		return
+	}
+
+	_ = logger.Record(ctx, ports.AuditEvent{
+		Subject:  claims.Subject,
+		Action:   ports.AuditActionAuthorize,
+		Resource: auditResource(c),
+		Decision: decision,
+		Outcome:  outcome,
+		Reason:   reason,
+		Details:  details,
+	})
+}
+
+// auditResource returns the method and route template of the request,
+// e.g. "GET /api/v1/quotes/:id", or the raw path for unmatched routes.
+func auditResource(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+
+	return c.Request.Method + " " + route
+}
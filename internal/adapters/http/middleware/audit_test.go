@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/ports"
+)
+
+// recordingAuditLogger captures audit events for assertions.
+type recordingAuditLogger struct {
+	mu     sync.Mutex
+	events []ports.AuditEvent
+}
+
+func (l *recordingAuditLogger) Record(_ context.Context, event ports.AuditEvent) error { //nolint:gocritic // ports.AuditLogger interface takes value
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+
+	return nil
+}
+
+// TestAudit_RecordsAuthorizationDecisions tests the events recorded by RequireRole and RequireScopes.
+func TestAudit_RecordsAuthorizationDecisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		guard        gin.HandlerFunc
+		headers      map[string]string
+		handlerCode  int
+		wantDecision string
+		wantOutcome  ports.AuditOutcome
+		wantDetails  map[string]any
+	}{
+		{
+			name:         "role allowed",
+			guard:        RequireRole(&config.AuthConfig{}, "admin"),
+			headers:      map[string]string{"X-User-ID": "user-123", "X-User-Roles": "admin"},
+			handlerCode:  http.StatusOK,
+			wantDecision: ports.AuditDecisionAllow,
+			wantOutcome:  ports.AuditOutcomeSuccess,
+			wantDetails:  map[string]any{"role": "admin", "status": http.StatusOK},
+		},
+		{
+			name:         "role denied",
+			guard:        RequireRole(&config.AuthConfig{}, "admin"),
+			headers:      map[string]string{"X-User-ID": "user-123", "X-User-Roles": "user"},
+			wantDecision: ports.AuditDecisionDeny,
+			wantOutcome:  ports.AuditOutcomeDenied,
+			wantDetails:  map[string]any{"role": "admin"},
+		},
+		{
+			name:         "scopes allowed but handler failed",
+			guard:        RequireScopes(&config.AuthConfig{}, "quotes:write"),
+			headers:      map[string]string{"X-User-ID": "user-123", "X-User-Scopes": "quotes:write"},
+			handlerCode:  http.StatusBadGateway,
+			wantDecision: ports.AuditDecisionAllow,
+			wantOutcome:  ports.AuditOutcomeFailure,
+			wantDetails:  map[string]any{"scopes": []string{"quotes:write"}, "status": http.StatusBadGateway},
+		},
+		{
+			name:         "scopes denied",
+			guard:        RequireScopes(&config.AuthConfig{}, "quotes:write"),
+			headers:      map[string]string{"X-User-ID": "user-123", "X-User-Scopes": "quotes:read"},
+			wantDecision: ports.AuditDecisionDeny,
+			wantOutcome:  ports.AuditOutcomeDenied,
+			wantDetails:  map[string]any{"scopes": []string{"quotes:write"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			audit := &recordingAuditLogger{}
+
+			router := gin.New()
+			router.Use(RequestID(), Audit(&config.AuthConfig{}, audit))
+			router.GET("/quotes/:id", tt.guard, func(c *gin.Context) {
+				c.Status(tt.handlerCode)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/quotes/42", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Len(t, audit.events, 1)
+
+			event := audit.events[0]
+			assert.Equal(t, "user-123", event.Subject)
+			assert.Equal(t, ports.AuditActionAuthorize, event.Action)
+			assert.Equal(t, "GET /quotes/:id", event.Resource)
+			assert.Equal(t, tt.wantDecision, event.Decision)
+			assert.Equal(t, tt.wantOutcome, event.Outcome)
+			assert.Equal(t, tt.wantDetails, event.Details)
+			assert.Equal(t, "req-1", event.RequestID)
+		})
+	}
+}
+
+// TestAudit_HandlerEventsAreStamped tests that events recorded downstream get the request fields.
+func TestAudit_HandlerEventsAreStamped(t *testing.T) {
+	t.Parallel()
+
+	audit := &recordingAuditLogger{}
+
+	router := gin.New()
+	router.Use(RequestID(), CorrelationID(), Audit(&config.AuthConfig{}, audit))
+	router.POST("/quotes", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		_ = ports.GetAuditLogger(ctx).Record(ctx, ports.AuditEvent{
+			Action:  ports.AuditActionCommit,
+			Outcome: ports.AuditOutcomeSuccess,
+		})
+		c.Status(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes", nil)
+	req.Header.Set("X-User-ID", "user-123")
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("X-Correlation-ID", "corr-1")
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, audit.events, 1)
+	assert.Equal(t, "user-123", audit.events[0].Subject)
+	assert.Equal(t, "POST /quotes", audit.events[0].Resource)
+	assert.Equal(t, "req-1", audit.events[0].RequestID)
+	assert.Equal(t, "corr-1", audit.events[0].CorrelationID)
+}
+
+// TestAudit_NotConfigured tests that the auth middleware works without an audit logger.
+func TestAudit_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	router := gin.New()
+	router.GET("/admin", RequireRole(&config.AuthConfig{}, "admin"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("X-User-Roles", "admin")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
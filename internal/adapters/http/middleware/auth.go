@@ -130,15 +130,16 @@ func GetClaims(c *gin.Context) *Claims {
 func RequireAuth(cfg *config.AuthConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := ExtractClaims(c, cfg)
+		requirement := map[string]any{"authenticated": true}
 
 		if claims.Subject == "" {
-			abortWithForbidden(c, "authentication required")
+			forbid(c, claims, "authentication required", requirement)
 			return
 		}
 
 		// Store claims in context
 		c.Set(ContextKeyClaims, claims)
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -147,12 +148,14 @@ func RequireRole(cfg *config.AuthConfig, role string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
 
+		requirement := map[string]any{"role": role}
+
 		if !claims.HasRole(role) {
-			abortWithForbidden(c, "insufficient permissions: role "+role+" required")
+			forbid(c, claims, "insufficient permissions: role "+role+" required", requirement)
 			return
 		}
 
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -161,12 +164,14 @@ func RequireAnyRole(cfg *config.AuthConfig, roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
 
+		requirement := map[string]any{"any_role": roles}
+
 		if !claims.HasAnyRole(roles...) {
-			abortWithForbidden(c, "insufficient permissions: one of roles ["+strings.Join(roles, ", ")+"] required")
+			forbid(c, claims, "insufficient permissions: one of roles ["+strings.Join(roles, ", ")+"] required", requirement)
 			return
 		}
 
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -175,12 +180,14 @@ func RequireScopes(cfg *config.AuthConfig, scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
 
+		requirement := map[string]any{"scopes": scopes}
+
 		if !claims.HasAllScopes(scopes...) {
-			abortWithForbidden(c, "insufficient permissions: scopes ["+strings.Join(scopes, ", ")+"] required")
+			forbid(c, claims, "insufficient permissions: scopes ["+strings.Join(scopes, ", ")+"] required", requirement)
 			return
 		}
 
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -189,12 +196,14 @@ func RequireAnyScope(cfg *config.AuthConfig, scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
 
+		requirement := map[string]any{"any_scope": scopes}
+
 		if !claims.HasAnyScope(scopes...) {
-			abortWithForbidden(c, "insufficient permissions: one of scopes ["+strings.Join(scopes, ", ")+"] required")
+			forbid(c, claims, "insufficient permissions: one of scopes ["+strings.Join(scopes, ", ")+"] required", requirement)
 			return
 		}
 
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -203,12 +212,14 @@ func RequirePermission(cfg *config.AuthConfig, perm string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
 
+		requirement := map[string]any{"permission": perm}
+
 		if !claims.HasPermission(perm) {
-			abortWithForbidden(c, "insufficient permissions: permission "+perm+" required")
+			forbid(c, claims, "insufficient permissions: permission "+perm+" required", requirement)
 			return
 		}
 
-		c.Next()
+		authorize(c, claims, requirement)
 	}
 }
 
@@ -224,16 +235,17 @@ func RequirePermission(cfg *config.AuthConfig, perm string) gin.HandlerFunc {
 func RequireAny(cfg *config.AuthConfig, checks ...func(*Claims) bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims := getOrExtractClaims(c, cfg)
+		requirement := map[string]any{"any_check": len(checks)}
 
 		for _, check := range checks {
 			if check(claims) {
-				c.Next()
+				authorize(c, claims, requirement)
 				return
 			}
 		}
 
 		// None of the checks passed
-		abortWithForbidden(c, "insufficient permissions")
+		forbid(c, claims, "insufficient permissions", requirement)
 	}
 }
 
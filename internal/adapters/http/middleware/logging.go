@@ -11,69 +11,49 @@ import (
 	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 )
 
+// LoggingConfig configures the request logging middleware.
+type LoggingConfig struct {
+	// Redactor redacts query parameter and header values per key before
+	// they are logged. Defaults to the built-in secret rules.
+	Redactor *logging.Redactor
+
+	// Headers are request headers added to "request started" records,
+	// under the headers group with lower-case names.
+	Headers []string
+
+	// SkipPaths are paths that are not logged, in addition to /-/ paths.
+	SkipPaths []string
+}
+
 // Logging returns middleware that logs HTTP requests.
 // It logs:
 //   - Request start: method, path, request_id, correlation_id
 //   - Request completion: status, latency, bytes written
 //
 // Health check paths (starting with /-/) are skipped to avoid log noise.
+// Query parameter values are redacted with the built-in secret rules; use
+// LoggingWithConfig to apply the configured redaction policy.
 func Logging(logger *slog.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Skip logging for health check endpoints
-		if strings.HasPrefix(c.Request.URL.Path, "/-/") {
-			c.Next()
-			return
-		}
-
-		start := time.Now()
-
-		path := c.Request.URL.Path
-		if c.Request.URL.RawQuery != "" {
-			path = path + "?" + c.Request.URL.RawQuery
-		}
-
-		// Get context logger (enriched with request_id, correlation_id, trace_id)
-		ctxLogger := logging.FromContext(c.Request.Context())
-
-		// Log request start
-		ctxLogger.Info("request started",
-			slog.String("method", c.Request.Method),
-			slog.String("path", path),
-			slog.String("client_ip", c.ClientIP()),
-			slog.String("user_agent", c.Request.UserAgent()),
-		)
-
-		// Process request
-		c.Next()
-
-		// Log request completion
-		latency := time.Since(start)
-		status := c.Writer.Status()
-		size := c.Writer.Size()
-
-		// Choose log level based on status code
-		level := slog.LevelInfo
-		if status >= http.StatusInternalServerError {
-			level = slog.LevelError
-		} else if status >= http.StatusBadRequest {
-			level = slog.LevelWarn
-		}
-
-		ctxLogger.Log(c.Request.Context(), level, "request completed",
-			slog.String("method", c.Request.Method),
-			slog.String("path", path),
-			slog.Int("status", status),
-			slog.Duration("latency", latency),
-			slog.Int64("latency_ms", latency.Milliseconds()),
-			slog.Int("bytes", size),
-		)
-	}
+	return LoggingWithConfig(logger, LoggingConfig{})
 }
 
 // LoggingWithSkipPaths returns logging middleware with configurable paths to skip.
 func LoggingWithSkipPaths(logger *slog.Logger, skipPaths []string) gin.HandlerFunc {
-	skipMap := make(map[string]struct{}, len(skipPaths))
-	for _, path := range skipPaths {
+	return LoggingWithConfig(logger, LoggingConfig{SkipPaths: skipPaths})
+}
+
+// LoggingWithConfig returns logging middleware configured by cfg. The path
+// is logged with its query string, each parameter value redacted per key
+// (?api_key=[REDACTED]&page=2), since the redaction rules only see the
+// attribute name "path" otherwise. Selected headers are redacted the same way.
+func LoggingWithConfig(_ *slog.Logger, cfg LoggingConfig) gin.HandlerFunc {
+	redactor := cfg.Redactor
+	if redactor == nil {
+		redactor = logging.NewRedactor(nil, logging.RedactConfig{})
+	}
+
+	skipMap := make(map[string]struct{}, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
 		skipMap[path] = struct{}{}
 	}
 
@@ -86,7 +66,7 @@ func LoggingWithSkipPaths(logger *slog.Logger, skipPaths []string) gin.HandlerFu
 			return
 		}
 
-		// Check prefix match for /-/ paths
+		// Skip logging for health check endpoints
 		if strings.HasPrefix(path, "/-/") {
 			c.Next()
 			return
@@ -94,25 +74,37 @@ func LoggingWithSkipPaths(logger *slog.Logger, skipPaths []string) gin.HandlerFu
 
 		start := time.Now()
 
-		fullPath := path
 		if c.Request.URL.RawQuery != "" {
-			fullPath = fullPath + "?" + c.Request.URL.RawQuery
+			path = path + "?" + redactor.Query(c.Request.URL.RawQuery)
 		}
 
+		// Get context logger (enriched with request_id and correlation_id; trace_id
+		// is added from the span in the request context)
 		ctxLogger := logging.FromContext(c.Request.Context())
 
-		ctxLogger.Info("request started",
+		attrs := []any{
 			slog.String("method", c.Request.Method),
-			slog.String("path", fullPath),
+			slog.String("path", path),
 			slog.String("client_ip", c.ClientIP()),
-		)
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
 
+		if headers := requestHeaders(c.Request.Header, cfg.Headers, redactor); len(headers) > 0 {
+			attrs = append(attrs, slog.Group("headers", headers...))
+		}
+
+		// Log request start
+		ctxLogger.InfoContext(c.Request.Context(), "request started", attrs...)
+
+		// Process request
 		c.Next()
 
+		// Log request completion
 		latency := time.Since(start)
 		status := c.Writer.Status()
 		size := c.Writer.Size()
 
+		// Choose log level based on status code
 		level := slog.LevelInfo
 		if status >= http.StatusInternalServerError {
 			level = slog.LevelError
@@ -122,10 +114,28 @@ func LoggingWithSkipPaths(logger *slog.Logger, skipPaths []string) gin.HandlerFu
 
 		ctxLogger.Log(c.Request.Context(), level, "request completed",
 			slog.String("method", c.Request.Method),
-			slog.String("path", fullPath),
+			slog.String("path", path),
 			slog.Int("status", status),
 			slog.Duration("latency", latency),
+			slog.Int64("latency_ms", latency.Milliseconds()),
 			slog.Int("bytes", size),
 		)
 	}
 }
+
+// requestHeaders returns the named headers present in h as attributes with
+// lower-case keys and redacted values. Repeated headers are joined with ", ".
+func requestHeaders(h http.Header, names []string, redactor *logging.Redactor) []any {
+	attrs := make([]any, 0, len(names))
+
+	for _, name := range names {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		attrs = append(attrs, slog.String(strings.ToLower(name), redactor.Value(name, strings.Join(values, ", "))))
+	}
+
+	return attrs
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"bytes"
 	"io"
 	"log/slog"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 )
 
 func init() {
@@ -1018,6 +1020,41 @@ func TestLogging(t *testing.T) {
 	})
 }
 
+// TestLoggingWithConfig_Redaction tests that query parameters and headers are redacted per key.
+func TestLoggingWithConfig_Redaction(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(logging.WithContext(c.Request.Context(), logger))
+		c.Next()
+	})
+	router.Use(LoggingWithConfig(logger, LoggingConfig{
+		Redactor: logging.NewRedactor(nil, logging.RedactConfig{Fields: []string{"session_id"}}),
+		Headers:  []string{"Authorization", "X-Forwarded-For", "X-Missing"},
+	}))
+	router.GET("/api/search", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search?q=hello&api_key=abc123&session_id=s-42", nil)
+	req.Header.Set("Authorization", "opaque-credential")
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	output := buf.String()
+	assert.Contains(t, output, `"path":"/api/search?q=hello&api_key=[REDACTED]&session_id=[REDACTED]"`)
+	assert.Contains(t, output, `"headers":{"authorization":"[REDACTED]","x-forwarded-for":"10.0.0.1"}`)
+	assert.NotContains(t, output, "abc123")
+	assert.NotContains(t, output, "s-42")
+	assert.NotContains(t, output, "opaque-credential")
+	assert.NotContains(t, output, "x-missing")
+}
+
 // TestLoggingWithSkipPaths tests the LoggingWithSkipPaths middleware.
 func TestLoggingWithSkipPaths(t *testing.T) {
 	t.Parallel()
@@ -57,12 +57,11 @@ func Recovery(logger *slog.Logger) gin.HandlerFunc {
 				ctxLogger := logging.FromContext(c.Request.Context())
 				traceID := extractTraceID(c.Request.Context())
 
-				ctxLogger.Error("panic recovered",
+				ctxLogger.ErrorContext(c.Request.Context(), "panic recovered",
 					slog.Any("error", r),
 					slog.String("stack", string(stack)),
 					slog.String("path", c.Request.URL.Path),
 					slog.String("method", c.Request.Method),
-					slog.String("trace_id", traceID),
 				)
 
 				sendPanicResponse(c, traceID)
@@ -88,10 +87,9 @@ func RecoveryWithWriter(logger *slog.Logger, stackHandler func(err any, stack []
 				ctxLogger := logging.FromContext(c.Request.Context())
 				traceID := extractTraceID(c.Request.Context())
 
-				ctxLogger.Error("panic recovered",
+				ctxLogger.ErrorContext(c.Request.Context(), "panic recovered",
 					slog.Any("error", r),
 					slog.String("stack", string(stack)),
-					slog.String("trace_id", traceID),
 				)
 
 				sendPanicResponse(c, traceID)
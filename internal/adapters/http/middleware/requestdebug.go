@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+// RequestDebug returns middleware that turns on trace logging for a single
+// request. When the request sets cfg.Header (e.g. X-Debug-Log) to "true" and
+// the caller's subject or one of its roles is allow-listed in cfg, the
+// request context gets logging.LevelTrace via logging.WithLevel. Every
+// record logged with that context, including in clients and ACL adapters,
+// is then emitted regardless of the global or per-component level.
+//
+// The header is ignored for callers that are not allow-listed. Must run
+// after RequestID so the request-scoped logger is in the context.
+func RequestDebug(authCfg *config.AuthConfig, cfg *config.LogRequestDebugConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.EqualFold(c.GetHeader(cfg.Header), "true") {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		claims := getOrExtractClaims(c, authCfg)
+
+		allowed := (claims.Subject != "" && slices.Contains(cfg.AllowedSubjects, claims.Subject)) ||
+			claims.HasAnyRole(cfg.AllowedRoles...)
+		if !allowed {
+			logging.FromContext(ctx).DebugContext(ctx, "ignoring debug log header from caller not allow-listed",
+				slog.String("subject", claims.Subject),
+			)
+			c.Next()
+
+			return
+		}
+
+		ctx = logging.WithLevel(ctx, logging.LevelTrace)
+		c.Request = c.Request.WithContext(ctx)
+
+		logging.FromContext(ctx).InfoContext(ctx, "request debug logging enabled",
+			slog.String("subject", claims.Subject),
+		)
+
+		c.Next()
+	}
+}
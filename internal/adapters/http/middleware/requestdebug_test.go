@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+// TestRequestDebug tests that only allow-listed callers can turn on trace logging.
+func TestRequestDebug(t *testing.T) {
+	t.Parallel()
+
+	debugCfg := &config.LogRequestDebugConfig{
+		Enabled:         true,
+		Header:          "X-Debug-Log",
+		AllowedSubjects: []string{"oncall-bot"},
+		AllowedRoles:    []string{"admin"},
+	}
+
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		wantTrace bool
+	}{
+		{
+			name:      "allow-listed role",
+			headers:   map[string]string{"X-Debug-Log": "true", "X-User-Roles": "admin"},
+			wantTrace: true,
+		},
+		{
+			name:      "allow-listed subject",
+			headers:   map[string]string{"X-Debug-Log": "TRUE", "X-User-ID": "oncall-bot"},
+			wantTrace: true,
+		},
+		{
+			name:    "caller not allow-listed",
+			headers: map[string]string{"X-Debug-Log": "true", "X-User-ID": "someone", "X-User-Roles": "user"},
+		},
+		{
+			name:    "header not set",
+			headers: map[string]string{"X-User-Roles": "admin"},
+		},
+		{
+			name:    "header not true",
+			headers: map[string]string{"X-Debug-Log": "1", "X-User-Roles": "admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := logging.NewWithWriter(&logging.Config{Level: "info", Format: "json"}, &buf)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Request = c.Request.WithContext(logging.WithContext(c.Request.Context(), logger))
+				c.Next()
+			})
+			router.Use(RequestDebug(&config.AuthConfig{}, debugCfg))
+			router.GET("/test", func(c *gin.Context) {
+				ctx := c.Request.Context()
+				logging.FromContext(ctx).Log(ctx, logging.LevelTrace, "downstream trace")
+				logger.Debug("no context debug")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantTrace {
+				assert.Contains(t, buf.String(), "downstream trace")
+				assert.Contains(t, buf.String(), "request debug logging enabled")
+			} else {
+				assert.NotContains(t, buf.String(), "downstream trace")
+			}
+
+			assert.NotContains(t, buf.String(), "no context debug", "global level is unchanged")
+		})
+	}
+}
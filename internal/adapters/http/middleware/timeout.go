@@ -98,11 +98,10 @@ func handleTimeout(c *gin.Context, timeout time.Duration) {
 		traceID = span.SpanContext().TraceID().String()
 	}
 
-	ctxLogger.Warn("request timeout",
+	ctxLogger.WarnContext(c.Request.Context(), "request timeout",
 		slog.String("path", c.Request.URL.Path),
 		slog.String("method", c.Request.Method),
 		slog.Duration("timeout", timeout),
-		slog.String("trace_id", traceID),
 	)
 
 	errResp := dto.NewErrorResponse(
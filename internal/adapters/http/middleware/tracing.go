@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
+)
+
+// Span event names recorded by SpanEnrichment.
+const (
+	SpanEventPanic   = "panic"
+	SpanEventTimeout = "timeout"
+)
+
+// SpanEnrichment returns middleware that adds request details to the server
+// span started by telemetry.TracingMiddleware:
+//   - request_id and correlation_id, as in the request logs
+//   - enduser.id, the caller's subject from Claims (when known)
+//   - http.route, the matched route
+//
+// It also records a "panic" event (with the value and stack) before
+// re-panicking to Recovery, and a "timeout" event when the request deadline
+// set by Timeout or SimpleTimeout expires. Both set the span status to
+// error.
+//
+// Must run after RequestID, CorrelationID and the tracing middleware.
+func SpanEnrichment(authCfg *config.AuthConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		span := trace.SpanFromContext(c.Request.Context())
+		if !span.IsRecording() {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		span.SetAttributes(
+			attribute.String(logging.RequestIDKey, RequestIDFromContext(ctx)),
+			attribute.String(logging.CorrelationIDKey, CorrelationIDFromContext(ctx)),
+		)
+
+		if route := c.FullPath(); route != "" {
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				span.AddEvent(SpanEventPanic, trace.WithAttributes(
+					semconv.ExceptionType(fmt.Sprintf("%T", r)),
+					semconv.ExceptionMessage(fmt.Sprint(r)),
+					semconv.ExceptionStacktrace(string(debug.Stack())),
+				))
+				span.SetStatus(codes.Error, "panic")
+
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		// Claims are set by auth middleware further down the chain, if any
+		if claims := getOrExtractClaims(c, authCfg); claims.Subject != "" {
+			span.SetAttributes(semconv.EnduserID(claims.Subject))
+		}
+
+		// Timeout middleware replaces the request context with its own
+		if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+			span.AddEvent(SpanEventTimeout)
+			span.SetStatus(codes.Error, "request timeout")
+		}
+	}
+}
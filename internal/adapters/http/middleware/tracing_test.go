@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+)
+
+// newTracedEngine returns an engine whose requests get a recorded server
+// span ahead of SpanEnrichment, standing in for the otelgin middleware.
+func newTracedEngine(t *testing.T) (*gin.Engine, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	engine := gin.New()
+	engine.Use(
+		Recovery(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		RequestID(),
+		CorrelationID(),
+		func(c *gin.Context) {
+			ctx, span := tracer.Start(c.Request.Context(), "server", trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+
+			c.Request = c.Request.WithContext(ctx)
+			c.Next()
+		},
+		SpanEnrichment(&config.AuthConfig{}),
+	)
+
+	return engine, recorder
+}
+
+// spanAttrs returns the attributes of the only ended span.
+func spanAttrs(t *testing.T, recorder *tracetest.SpanRecorder) (sdktrace.ReadOnlySpan, map[attribute.Key]string) {
+	t.Helper()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range spans[0].Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+
+	return spans[0], attrs
+}
+
+// TestSpanEnrichment_Attributes tests the request attributes added to the server span.
+func TestSpanEnrichment_Attributes(t *testing.T) {
+	t.Parallel()
+
+	engine, recorder := newTracedEngine(t)
+	engine.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req.Header.Set(HeaderRequestID, "req-1")
+	req.Header.Set(HeaderCorrelationID, "corr-1")
+	req.Header.Set("X-User-ID", "user-123")
+
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	span, attrs := spanAttrs(t, recorder)
+	assert.Equal(t, "req-1", attrs["request_id"])
+	assert.Equal(t, "corr-1", attrs["correlation_id"])
+	assert.Equal(t, "user-123", attrs["enduser.id"])
+	assert.Equal(t, "/items/:id", attrs["http.route"])
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Empty(t, span.Events())
+}
+
+// TestSpanEnrichment_Panic tests that a panic is recorded as a span event and still recovered.
+func TestSpanEnrichment_Panic(t *testing.T) {
+	t.Parallel()
+
+	engine, recorder := newTracedEngine(t)
+	engine.GET("/panic", func(*gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	span, _ := spanAttrs(t, recorder)
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	// The SDK adds its own exception event when the span ends while panicking
+	i := slices.IndexFunc(span.Events(), func(e sdktrace.Event) bool { return e.Name == SpanEventPanic })
+	require.GreaterOrEqual(t, i, 0, "panic event recorded")
+
+	attrs := make(map[attribute.Key]string)
+	for _, kv := range span.Events()[i].Attributes {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+
+	assert.Equal(t, "boom", attrs["exception.message"])
+	assert.Equal(t, "string", attrs["exception.type"])
+	assert.Contains(t, attrs["exception.stacktrace"], "tracing_test.go")
+}
+
+// TestSpanEnrichment_Timeout tests that an expired request deadline is recorded as a span event.
+func TestSpanEnrichment_Timeout(t *testing.T) {
+	t.Parallel()
+
+	engine, recorder := newTracedEngine(t)
+	engine.GET("/slow", SimpleTimeout(10*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.Status(http.StatusGatewayTimeout)
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	span, _ := spanAttrs(t, recorder)
+	assert.Equal(t, codes.Error, span.Status().Code)
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, SpanEventTimeout, span.Events()[0].Name)
+}
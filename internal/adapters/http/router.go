@@ -9,12 +9,17 @@ import (
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/handlers"
 	"github.com/jsamuelsen/go-service-template/internal/adapters/http/middleware"
 	"github.com/jsamuelsen/go-service-template/internal/platform/config"
+	"github.com/jsamuelsen/go-service-template/internal/platform/logging"
 	"github.com/jsamuelsen/go-service-template/internal/platform/telemetry"
+	"github.com/jsamuelsen/go-service-template/internal/ports"
 )
 
 // DefaultRequestTimeout is the default timeout for API requests.
 const DefaultRequestTimeout = 30 * time.Second
 
+// AdminRole is the role required by the operator endpoints under /-/.
+const AdminRole = "admin"
+
 // RouterConfig contains configuration for setting up the router.
 type RouterConfig struct {
 	// Logger is the structured logger for request logging.
@@ -32,6 +37,31 @@ type RouterConfig struct {
 	// QuoteHandler handles quote endpoints (optional).
 	QuoteHandler *handlers.QuoteHandler
 
+	// LogLevelHandler handles runtime log level endpoints (optional).
+	// Its routes require AdminRole.
+	LogLevelHandler *handlers.LogLevelHandler
+
+	// RequestDebug enables per-request trace logging via a header (optional).
+	RequestDebug *config.LogRequestDebugConfig
+
+	// LogsHandler serves the in-memory log buffer (optional).
+	// Its routes require AdminRole.
+	LogsHandler *handlers.LogsHandler
+
+	// ConfigHandler serves the effective configuration (optional).
+	// Its routes require AdminRole.
+	ConfigHandler *handlers.ConfigHandler
+
+	// AuditLogger records authorization decisions and commits (optional).
+	AuditLogger ports.AuditLogger
+
+	// LogRedactor redacts query parameters and LogHeaders in request logs
+	// (optional; defaults to the built-in secret rules).
+	LogRedactor *logging.Redactor
+
+	// LogHeaders are request headers included in request logs (optional).
+	LogHeaders []string
+
 	// Timeout is the default request timeout.
 	Timeout time.Duration
 }
@@ -41,28 +71,66 @@ type RouterConfig struct {
 //  1. Recovery - catch panics first
 //  2. Request ID - generate/extract request ID
 //  3. Correlation ID - handle distributed tracing correlation
-//  4. OpenTelemetry - tracing and metrics
-//  5. Logging - request logging (skips health endpoints)
-//  6. Timeout - request deadline (applied per-route or globally)
+//  4. Request debug - per-request trace logging via header (when enabled)
+//  5. Audit - request-scoped audit logger (when configured)
+//  6. OpenTelemetry - server span from the incoming traceparent
+//  7. Span enrichment - request and correlation IDs, subject, route, panics, timeouts
+//  8. Metrics - HTTP server metrics and X-Trace-ID header
+//  9. Logging - request logging (skips health endpoints)
+//  10. Timeout - request deadline (applied per-route or globally)
 //
 // Route groups:
 //   - /-/ (internal): Health endpoints, no auth required
+//   - /-/ (admin): Operator endpoints such as log levels, buffered logs and config, AdminRole required
 //   - /api/v1/ (public API): Business endpoints, auth as needed
 func SetupRouter(engine *gin.Engine, cfg RouterConfig) {
 	// Apply global middleware in order
-	engine.Use(
+	chain := []gin.HandlerFunc{
 		middleware.Recovery(cfg.Logger),
 		middleware.RequestID(),
 		middleware.CorrelationID(),
+	}
+
+	if cfg.RequestDebug != nil && cfg.RequestDebug.Enabled {
+		chain = append(chain, middleware.RequestDebug(cfg.AuthConfig, cfg.RequestDebug))
+	}
+
+	if cfg.AuditLogger != nil {
+		chain = append(chain, middleware.Audit(cfg.AuthConfig, cfg.AuditLogger))
+	}
+
+	engine.Use(append(chain,
+		telemetry.TracingMiddleware(cfg.AppConfig.Name),
+		middleware.SpanEnrichment(cfg.AuthConfig),
 		telemetry.Middleware(cfg.AppConfig.Name),
-		middleware.Logging(cfg.Logger),
-	)
+		middleware.LoggingWithConfig(cfg.Logger, middleware.LoggingConfig{
+			Redactor: cfg.LogRedactor,
+			Headers:  cfg.LogHeaders,
+		}),
+	)...)
 
 	// Register health endpoints (no auth, no timeout for probes)
 	if cfg.HealthHandler != nil {
 		cfg.HealthHandler.RegisterHealthRoutesOnEngine(engine)
 	}
 
+	// Register admin endpoints (role required, no timeout)
+	if cfg.LogLevelHandler != nil || cfg.LogsHandler != nil || cfg.ConfigHandler != nil {
+		admin := engine.Group("/-", middleware.RequireRole(cfg.AuthConfig, AdminRole))
+
+		if cfg.LogLevelHandler != nil {
+			cfg.LogLevelHandler.RegisterLogLevelRoutes(admin)
+		}
+
+		if cfg.LogsHandler != nil {
+			cfg.LogsHandler.RegisterLogsRoutes(admin)
+		}
+
+		if cfg.ConfigHandler != nil {
+			cfg.ConfigHandler.RegisterConfigRoutes(admin)
+		}
+	}
+
 	// Setup API v1 routes with timeout
 	apiV1 := engine.Group("/api/v1")
 	if cfg.Timeout > 0 {
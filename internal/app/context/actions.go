@@ -3,6 +3,8 @@ package context
 import (
 	"context"
 	"fmt"
+
+	"github.com/jsamuelsen/go-service-template/internal/ports"
 )
 
 // Action represents a staged write operation.
@@ -32,6 +34,7 @@ func (rc *RequestContext) AddAction(action Action) error {
 
 // Commit executes all staged actions in order.
 // On failure, rolls back executed actions in reverse order.
+// If ctx carries a ports.AuditLogger, the commit and its outcome are recorded.
 func (rc *RequestContext) Commit(ctx context.Context) error {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
@@ -48,15 +51,45 @@ func (rc *RequestContext) Commit(ctx context.Context) error {
 				// Log rollback error but continue
 				_ = executed[i].Rollback(ctx)
 			}
-			return fmt.Errorf("action %q failed: %w", action.Description(), err)
+			err = fmt.Errorf("action %q failed: %w", action.Description(), err)
+			auditCommit(ctx, rc.actions, err)
+			return err
 		}
 		executed = append(executed, action)
 	}
 
 	rc.committed = true
+	auditCommit(ctx, rc.actions, nil)
 	return nil
 }
 
+// auditCommit records a commit of actions to the request's audit logger, if any.
+// Commits without actions are not recorded.
+func auditCommit(ctx context.Context, actions []Action, err error) {
+	logger := ports.GetAuditLogger(ctx)
+	if logger == nil || len(actions) == 0 {
+		return
+	}
+
+	descriptions := make([]string, len(actions))
+	for i, action := range actions {
+		descriptions[i] = action.Description()
+	}
+
+	event := ports.AuditEvent{
+		Action:  ports.AuditActionCommit,
+		Outcome: ports.AuditOutcomeSuccess,
+		Details: map[string]any{"actions": descriptions},
+	}
+	if err != nil {
+		event.Outcome = ports.AuditOutcomeFailure
+		event.Reason = err.Error()
+	}
+
+	// The audit logger reports its own write failures; the commit result stands.
+	_ = logger.Record(ctx, event)
+}
+
 // Actions returns a copy of staged actions (for inspection/testing).
 func (rc *RequestContext) Actions() []Action {
 	rc.mu.Lock()
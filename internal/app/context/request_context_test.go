@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/jsamuelsen/go-service-template/internal/ports"
 )
 
 func TestNew(t *testing.T) {
@@ -276,3 +278,51 @@ func TestGetOrFetchProvider_CachesValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "cached-value", val2)
 }
+
+// recordingAuditLogger captures audit events for assertions.
+type recordingAuditLogger struct {
+	events []ports.AuditEvent
+}
+
+func (l *recordingAuditLogger) Record(_ context.Context, event ports.AuditEvent) error { //nolint:gocritic // ports.AuditLogger interface takes value
+	l.events = append(l.events, event)
+	return nil
+}
+
+func TestCommit_RecordsAuditEvent(t *testing.T) {
+	audit := &recordingAuditLogger{}
+	ctx := ports.WithAuditLogger(context.Background(), audit)
+	rc := New(ctx)
+
+	_ = rc.AddAction(&mockAction{description: "create quote"})
+	_ = rc.AddAction(&mockAction{description: "notify"})
+
+	require.NoError(t, rc.Commit(ctx))
+
+	require.Len(t, audit.events, 1)
+	assert.Equal(t, ports.AuditActionCommit, audit.events[0].Action)
+	assert.Equal(t, ports.AuditOutcomeSuccess, audit.events[0].Outcome)
+	assert.Equal(t, []string{"create quote", "notify"}, audit.events[0].Details["actions"])
+}
+
+func TestCommit_RecordsAuditFailure(t *testing.T) {
+	audit := &recordingAuditLogger{}
+	ctx := ports.WithAuditLogger(context.Background(), audit)
+	rc := New(ctx)
+
+	_ = rc.AddAction(&mockAction{description: "create quote", executeErr: errors.New("downstream unavailable")})
+
+	require.Error(t, rc.Commit(ctx))
+
+	require.Len(t, audit.events, 1)
+	assert.Equal(t, ports.AuditOutcomeFailure, audit.events[0].Outcome)
+	assert.Contains(t, audit.events[0].Reason, "downstream unavailable")
+}
+
+func TestCommit_EmptyActions_NotAudited(t *testing.T) {
+	audit := &recordingAuditLogger{}
+	ctx := ports.WithAuditLogger(context.Background(), audit)
+
+	require.NoError(t, New(ctx).Commit(ctx))
+	assert.Empty(t, audit.events)
+}
@@ -9,17 +9,24 @@ import (
 	"github.com/jsamuelsen/go-service-template/internal/ports"
 )
 
+// FlagQuotes is the feature flag that turns the quote use cases on or off
+// (default on). Turning it off makes them fail as unavailable, e.g. while the
+// quote service is degraded.
+const FlagQuotes = "quotes"
+
 // QuoteService orchestrates quote-related use cases.
 // It depends on port interfaces, not concrete implementations,
 // following the Dependency Inversion Principle.
 type QuoteService struct {
 	quoteClient ports.QuoteClient
+	flags       ports.FeatureFlags
 	logger      *slog.Logger
 }
 
 // QuoteServiceConfig contains configuration for the quote service.
 type QuoteServiceConfig struct {
 	QuoteClient ports.QuoteClient
+	Flags       ports.FeatureFlags // Optional; FlagQuotes is on without it
 	Logger      *slog.Logger
 }
 
@@ -37,14 +44,28 @@ func NewQuoteService(cfg QuoteServiceConfig) *QuoteService {
 
 	return &QuoteService{
 		quoteClient: cfg.QuoteClient,
+		flags:       cfg.Flags,
 		logger:      logger,
 	}
 }
 
+// checkEnabled returns an unavailable error if FlagQuotes is off.
+func (s *QuoteService) checkEnabled(ctx context.Context) error {
+	if s.flags == nil || s.flags.IsEnabled(ctx, FlagQuotes, true) {
+		return nil
+	}
+
+	return domain.NewUnavailableError("quote-service", "disabled by feature flag")
+}
+
 // GetRandomQuote retrieves a random quote from the external service.
 // This is a simple pass-through use case, but demonstrates the pattern.
 // More complex use cases would include caching, validation, or enrichment.
 func (s *QuoteService) GetRandomQuote(ctx context.Context) (*domain.Quote, error) {
+	if err := s.checkEnabled(ctx); err != nil {
+		return nil, err
+	}
+
 	s.logger.InfoContext(ctx, "fetching random quote")
 
 	quote, err := s.quoteClient.GetRandomQuote(ctx)
@@ -65,6 +86,10 @@ func (s *QuoteService) GetRandomQuote(ctx context.Context) (*domain.Quote, error
 
 // GetQuoteByID retrieves a specific quote by its identifier.
 func (s *QuoteService) GetQuoteByID(ctx context.Context, id string) (*domain.Quote, error) {
+	if err := s.checkEnabled(ctx); err != nil {
+		return nil, err
+	}
+
 	s.logger.InfoContext(ctx, "fetching quote by ID",
 		slog.String("quote_id", id),
 	)
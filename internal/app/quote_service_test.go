@@ -192,3 +192,25 @@ func TestQuoteService_GetQuoteByID(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteService_DisabledByFeatureFlag(t *testing.T) {
+	mockClient := mocks.NewMockQuoteClient(t)
+	mockFlags := mocks.NewMockFeatureFlags(t)
+	mockFlags.EXPECT().IsEnabled(mock.Anything, FlagQuotes, true).Return(false)
+
+	svc := NewQuoteService(QuoteServiceConfig{
+		QuoteClient: mockClient,
+		Flags:       mockFlags,
+		Logger:      discardLogger(),
+	})
+
+	quote, err := svc.GetRandomQuote(context.Background())
+	require.Error(t, err)
+	assert.True(t, domain.IsUnavailable(err))
+	assert.Nil(t, quote)
+
+	quote, err = svc.GetQuoteByID(context.Background(), "abc123")
+	require.Error(t, err)
+	assert.True(t, domain.IsUnavailable(err))
+	assert.Nil(t, quote)
+}
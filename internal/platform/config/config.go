@@ -2,19 +2,27 @@
 package config
 
 import (
-	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/env"
-	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 )
 
+// Configuration file locations, relative to the working directory.
+const (
+	// configDir is the directory containing base and profile config files.
+	configDir = "configs"
+
+	// baseConfigPath is the base config file shared by all profiles.
+	baseConfigPath = configDir + "/base.yaml"
+)
+
 // Default configuration values.
 const (
 	// DefaultServerPort is the default HTTP server port.
@@ -47,6 +55,18 @@ const (
 	// DefaultTransportIdleConnTimeout is the default idle connection timeout.
 	DefaultTransportIdleConnTimeout = 90 * time.Second
 
+	// DefaultLogSamplingFirst is the default number of records per message logged each window before sampling.
+	DefaultLogSamplingFirst = 100
+
+	// DefaultLogSamplingThereafter is the default sampling rate (every Nth record) after the first records.
+	DefaultLogSamplingThereafter = 100
+
+	// DefaultLogBufferSize is the default number of records kept in the in-memory log buffer.
+	DefaultLogBufferSize = 1000
+
+	// DefaultLogAsyncQueueSize is the default number of records the async log queue holds.
+	DefaultLogAsyncQueueSize = 1024
+
 	// DefaultLogFileMaxSizeMB is the default max log file size in megabytes.
 	DefaultLogFileMaxSizeMB = 100
 
@@ -55,114 +75,283 @@ const (
 
 	// DefaultLogFileMaxAgeDays is the default max days to retain old log files.
 	DefaultLogFileMaxAgeDays = 28
+
+	// DefaultAuditMaxSizeMB is the default max audit file size in megabytes.
+	DefaultAuditMaxSizeMB = 100
+
+	// DefaultAuditMaxAgeDays is the default max days to retain rotated audit files.
+	DefaultAuditMaxAgeDays = 365
 )
 
 // Config is the root configuration structure.
 type Config struct {
-	App       AppConfig       `koanf:"app"       validate:"required"`
-	Server    ServerConfig    `koanf:"server"    validate:"required"`
-	Log       LogConfig       `koanf:"log"       validate:"required"`
-	Telemetry TelemetryConfig `koanf:"telemetry"`
-	Auth      AuthConfig      `koanf:"auth"`
-	Client    ClientConfig    `koanf:"client"    validate:"required"`
-	Services  ServicesConfig  `koanf:"services"  validate:"required"`
+	App       AppConfig       `koanf:"app"       validate:"required"      desc:"Application identity"`
+	Server    ServerConfig    `koanf:"server"    validate:"required"      desc:"HTTP server settings"`
+	Log       LogConfig       `koanf:"log"       validate:"required"      desc:"Logging settings"`
+	Telemetry TelemetryConfig `koanf:"telemetry"                          desc:"OpenTelemetry tracing and metrics"`
+	Auth      AuthConfig      `koanf:"auth"                               desc:"Authentication settings"`
+	Audit     AuditConfig     `koanf:"audit"                              desc:"Append-only audit trail of authorization decisions and commits"`
+	Client    ClientConfig    `koanf:"client"    validate:"required"      desc:"Default HTTP client settings for downstream services"`
+	Services  ServicesConfig  `koanf:"services"  validate:"required,dive" desc:"Downstream service endpoints keyed by service name"`
+	Features  map[string]any  `koanf:"features"                           desc:"Feature toggles served through ports.FeatureFlags"`
+	Remote    RemoteConfig    `koanf:"remote"                             desc:"Central configuration source layered between the profile file and env vars"`
+
+	// secrets holds values resolved from secret references, keyed by config key.
+	secrets map[string]string
+
+	// origins records which layer supplied each effective key.
+	origins map[string]Origin
+
+	// values holds the flattened effective values, keyed by config key.
+	values map[string]any
+
+	// unknown holds keys that did not map to any field (non-strict profiles only).
+	unknown []UnknownKey
+
+	// remoteVersion is the version of the remote document that was loaded.
+	remoteVersion string
 }
 
 // AppConfig contains application-level settings.
 type AppConfig struct {
-	Name        string `koanf:"name"        validate:"required"`
-	Version     string `koanf:"version"     validate:"required"`
-	Environment string `koanf:"environment" validate:"required,oneof=local dev qa prod test"`
+	Name        string `koanf:"name"        validate:"required"                              desc:"Service name reported in logs and telemetry"`
+	Version     string `koanf:"version"     validate:"required"                              desc:"Service version reported in logs and telemetry"`
+	Environment string `koanf:"environment" validate:"required,oneof=local dev qa prod test" desc:"Deployment environment"`
 }
 
 // ServerConfig contains HTTP server settings.
 type ServerConfig struct {
-	Port            int           `koanf:"port"             validate:"required,min=1,max=65535"`
-	Host            string        `koanf:"host"             validate:"required"`
-	ReadTimeout     time.Duration `koanf:"read_timeout"     validate:"required,min=1s"`
-	WriteTimeout    time.Duration `koanf:"write_timeout"    validate:"required,min=1s"`
-	IdleTimeout     time.Duration `koanf:"idle_timeout"     validate:"required,min=1s"`
-	ShutdownTimeout time.Duration `koanf:"shutdown_timeout" validate:"required,min=1s"`
-	MaxRequestSize  int64         `koanf:"max_request_size" validate:"required,min=1"`
+	Port            int           `koanf:"port"             validate:"required,min=1,max=65535" desc:"HTTP listen port"`
+	Host            string        `koanf:"host"             validate:"required"                 desc:"HTTP listen address"`
+	ReadTimeout     time.Duration `koanf:"read_timeout"     validate:"required,min=1s"          desc:"Maximum duration for reading a request"`
+	WriteTimeout    time.Duration `koanf:"write_timeout"    validate:"required,min=1s"          desc:"Maximum duration for writing a response"`
+	IdleTimeout     time.Duration `koanf:"idle_timeout"     validate:"required,min=1s"          desc:"Maximum keep-alive idle duration"`
+	ShutdownTimeout time.Duration `koanf:"shutdown_timeout" validate:"required,min=1s"          desc:"Grace period for draining in-flight requests"`
+	MaxRequestSize  int64         `koanf:"max_request_size" validate:"required,min=1"           desc:"Maximum request body size in bytes"`
 }
 
 // LogConfig contains logging settings.
 type LogConfig struct {
-	Level  string        `koanf:"level"  validate:"required,oneof=trace debug info warn error"`
-	Format string        `koanf:"format" validate:"required,oneof=json text pretty"`
-	File   LogFileConfig `koanf:"file"`
+	Level        string                `koanf:"level"         validate:"required,oneof=trace debug info warn error" desc:"Minimum log level"`
+	Format       string                `koanf:"format"        validate:"required,oneof=json text pretty"            desc:"Console log format"`
+	File         LogFileConfig         `koanf:"file"                                                                desc:"Rolling JSON log file output"`
+	Sampling     LogSamplingConfig     `koanf:"sampling"                                                            desc:"Sampling and deduplication of info and debug records"`
+	Buffer       LogBufferConfig       `koanf:"buffer"                                                              desc:"In-memory buffer of recent records served at /-/logs"`
+	Async        LogAsyncConfig        `koanf:"async"                                                               desc:"Background writing of records through a bounded queue, flushed on shutdown"`
+	RequestDebug LogRequestDebugConfig `koanf:"request_debug"                                                       desc:"Per-request trace logging turned on by a header from allow-listed callers"`
+	Redact       LogRedactConfig       `koanf:"redact"                                                              desc:"Redaction policy applied on top of the built-in secret rules"`
+	Headers      []string              `koanf:"headers"                                                             desc:"Request headers added to request logs, redacted per header name"`
+}
+
+// LogRedactConfig contains the configurable log redaction policy. Built-in
+// secret field names and token patterns are always redacted in full; this
+// policy adds to them.
+type LogRedactConfig struct {
+	Fields    []string `koanf:"fields"                                                               desc:"Additional attribute names whose values are redacted"`
+	Patterns  []string `koanf:"patterns"                                                             desc:"Regular expressions; the matching parts of string values are redacted"`
+	Detectors []string `koanf:"detectors" validate:"dive,oneof=email card iban"                      desc:"Built-in PII detectors applied to string values"`
+	Style     string   `koanf:"style"     validate:"omitempty,oneof=full partial hash"               desc:"Replacement for policy matches: [REDACTED], ****1234, or a stable hash"`
+	HashKey   string   `koanf:"hash_key"                                               secret:"true" desc:"HMAC key for the hash style (required with it); use a secret reference"`
+}
+
+// Regexps compiles Patterns.
+func (r *LogRedactConfig) Regexps() ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(r.Patterns))
+
+	for i, pattern := range r.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("log.redact.patterns[%d]: %w", i, err)
+		}
+
+		res = append(res, re)
+	}
+
+	return res, nil
+}
+
+// LogRequestDebugConfig contains settings for per-request trace logging.
+// A caller is allow-listed when its subject or one of its roles (from the
+// auth headers) is listed.
+type LogRequestDebugConfig struct {
+	Enabled         bool     `koanf:"enabled"                                              desc:"Honor the debug header from allow-listed callers"`
+	Header          string   `koanf:"header"           validate:"required_if=Enabled true" desc:"Request header that turns on trace logging when set to true"`
+	AllowedSubjects []string `koanf:"allowed_subjects"                                     desc:"Caller subjects allowed to turn on trace logging"`
+	AllowedRoles    []string `koanf:"allowed_roles"                                        desc:"Caller roles allowed to turn on trace logging"`
+}
+
+// LogBufferConfig contains in-memory log buffer settings.
+type LogBufferConfig struct {
+	Enabled bool `koanf:"enabled"                                                                desc:"Keep recent records in memory for the /-/logs endpoint"`
+	Size    int  `koanf:"size"    validate:"required_if=Enabled true,omitempty,min=1,max=100000" desc:"Number of most recent records kept"`
+}
+
+// LogAsyncConfig contains async logging settings. Records are written to the
+// outputs by a background goroutine; the overflow policy decides what
+// happens when the queue is full.
+type LogAsyncConfig struct {
+	Enabled   bool   `koanf:"enabled"                                                                                      desc:"Write records in the background instead of on the logging goroutine"`
+	QueueSize int    `koanf:"queue_size" validate:"required_if=Enabled true,omitempty,min=1,max=1000000"                   desc:"Number of records queued before the overflow policy applies"`
+	Policy    string `koanf:"policy"     validate:"required_if=Enabled true,omitempty,oneof=block drop_oldest drop_newest" desc:"When the queue is full: block the caller, drop the oldest queued record, or drop the new one"`
+}
+
+// LogSamplingConfig contains log sampling settings. Warn and error records
+// are never sampled.
+type LogSamplingConfig struct {
+	Enabled    bool          `koanf:"enabled"                                                           desc:"Sample high-volume info and debug records"`
+	Window     time.Duration `koanf:"window"     validate:"required_if=Enabled true,omitempty,min=10ms" desc:"Period after which counters reset and a dropped-records summary is logged"`
+	First      int           `koanf:"first"      validate:"min=0"                                       desc:"Records per message logged in each window before sampling starts"`
+	Thereafter int           `koanf:"thereafter" validate:"min=0"                                       desc:"After first, log every Nth record per message; 0 drops the rest of the window"`
+	Dedup      bool          `koanf:"dedup"                                                             desc:"Drop records identical to one already logged in the window"`
 }
 
 // LogFileConfig contains rolling log file settings.
 type LogFileConfig struct {
-	Enabled    bool   `koanf:"enabled"`
-	Path       string `koanf:"path"       validate:"required_if=Enabled true"`
-	MaxSizeMB  int    `koanf:"max_size"   validate:"omitempty,min=1,max=1024"`
-	MaxBackups int    `koanf:"max_backups" validate:"omitempty,min=0,max=100"`
-	MaxAgeDays int    `koanf:"max_age"    validate:"omitempty,min=0,max=365"`
-	Compress   bool   `koanf:"compress"`
+	Enabled    bool   `koanf:"enabled"                                         desc:"Also write JSON logs to a rolling file"`
+	Path       string `koanf:"path"        validate:"required_if=Enabled true" desc:"Log file path"`
+	MaxSizeMB  int    `koanf:"max_size"    validate:"omitempty,min=1,max=1024" desc:"Maximum file size in megabytes before rotation"`
+	MaxBackups int    `koanf:"max_backups" validate:"omitempty,min=0,max=100"  desc:"Number of rotated files to keep"`
+	MaxAgeDays int    `koanf:"max_age"     validate:"omitempty,min=0,max=365"  desc:"Days to keep rotated files"`
+	Compress   bool   `koanf:"compress"                                        desc:"Gzip rotated files"`
 }
 
 // TelemetryConfig contains OpenTelemetry settings.
 type TelemetryConfig struct {
-	Enabled      bool    `koanf:"enabled"`
-	Endpoint     string  `koanf:"endpoint"      validate:"required_if=Enabled true,omitempty,url"`
-	ServiceName  string  `koanf:"service_name"  validate:"required_if=Enabled true"`
-	SamplingRate float64 `koanf:"sampling_rate" validate:"min=0,max=1"`
+	Enabled         bool                    `koanf:"enabled"                                                                                   desc:"Export traces and metrics"`
+	Exporter        string                  `koanf:"exporter"         validate:"omitempty,oneof=otlp-grpc otlp-http stdout file"               desc:"Where telemetry is sent: an OTLP collector over gRPC or HTTP, the console, or a file"`
+	Endpoint        string                  `koanf:"endpoint"         validate:"omitempty,url"                                                 desc:"OTLP collector endpoint; http is plaintext, https is TLS"`
+	ServiceName     string                  `koanf:"service_name"     validate:"required_if=Enabled true"                                      desc:"service.name resource attribute"`
+	SamplingRate    float64                 `koanf:"sampling_rate"    validate:"min=0,max=1"                                                   desc:"Fraction of traces to sample"`
+	Sampling        TelemetrySamplingConfig `koanf:"sampling"                                                                                  desc:"Per-route ratios, a rate limit and error keeping on top of sampling_rate"`
+	MetricsInterval time.Duration           `koanf:"metrics_interval" validate:"omitempty,min=1s"                                              desc:"How often metrics are exported"`
+	Prometheus      bool                    `koanf:"prometheus"                                                                                desc:"Expose metrics on /-/metrics, even with telemetry disabled"`
+	Logs            bool                    `koanf:"logs"                                                                                      desc:"Also export logs via OTLP (requires enabled and an otlp exporter)"`
+	TLS             TelemetryTLSConfig      `koanf:"tls"                                                                                       desc:"TLS for an https endpoint (CA bundle, client certificate, server name, min version)"`
+	Headers         map[string]string       `koanf:"headers"                                                                     secret:"true" desc:"Headers sent with every export request, e.g. a collector API key; values may be secret references"`
+	BearerToken     string                  `koanf:"bearer_token"                                                                secret:"true" desc:"Sent as Authorization: Bearer <token>; use a secret reference"`
+	OTLP            TelemetryOTLPConfig     `koanf:"otlp"                                                                                      desc:"Options for the otlp-grpc and otlp-http exporters"`
+	Stdout          TelemetryStdoutConfig   `koanf:"stdout"                                                                                    desc:"Options for the stdout exporter"`
+	File            TelemetryFileConfig     `koanf:"file"                                                                                      desc:"Options for the file exporter"`
+}
+
+// TelemetrySamplingConfig refines trace sampling. Routes match the request
+// path prefix of server spans, longest first; a ratio of zero never samples
+// the route, even when the caller's trace is sampled.
+type TelemetrySamplingConfig struct {
+	Routes     map[string]float64 `koanf:"routes"      validate:"dive,keys,startswith=/,endkeys,min=0,max=1" desc:"Sampling ratio by request path prefix, e.g. /-/: 0"`
+	RateLimit  float64            `koanf:"rate_limit"  validate:"min=0"                                      desc:"Maximum traces started per second; 0 is unlimited"`
+	KeepErrors bool               `koanf:"keep_errors"                                                       desc:"Record spans sampling dropped and export those ending in error"`
+}
+
+// TelemetryOTLPConfig contains options shared by the OTLP exporters.
+type TelemetryOTLPConfig struct {
+	Compression string        `koanf:"compression" validate:"omitempty,oneof=none gzip" desc:"Payload compression"`
+	Timeout     time.Duration `koanf:"timeout"     validate:"omitempty,min=100ms"       desc:"Timeout of each export request"`
+}
+
+// TelemetryStdoutConfig contains options for the stdout exporter, which
+// prints spans as they end.
+type TelemetryStdoutConfig struct {
+	Pretty  bool `koanf:"pretty"  desc:"Indent the JSON output"`
+	Metrics bool `koanf:"metrics" desc:"Also print metrics every metrics_interval"`
+}
+
+// TelemetryFileConfig contains options for the file exporter, which appends
+// spans and metrics as JSON.
+type TelemetryFileConfig struct {
+	Path   string `koanf:"path"   validate:"required_if=Exporter file" desc:"File spans and metrics are appended to"`
+	Pretty bool   `koanf:"pretty"                                      desc:"Indent the JSON output"`
+}
+
+// TelemetryTLSConfig contains TLS settings for the OTLP exporters. They
+// apply when telemetry.endpoint is an https URL; an http endpoint is
+// plaintext.
+type TelemetryTLSConfig struct {
+	CAFile     string `koanf:"ca_file"                                        desc:"PEM bundle of CAs trusted for the collector instead of the system roots"`
+	CertFile   string `koanf:"cert_file"   validate:"required_with=KeyFile"   desc:"PEM client certificate for mutual TLS"`
+	KeyFile    string `koanf:"key_file"    validate:"required_with=CertFile"  desc:"PEM private key of the client certificate"`
+	ServerName string `koanf:"server_name"                                    desc:"Name verified against the collector certificate instead of the endpoint host"`
+	MinVersion string `koanf:"min_version" validate:"omitempty,oneof=1.2 1.3" desc:"Minimum TLS version"`
 }
 
 // AuthConfig contains authentication settings.
 type AuthConfig struct {
-	Enabled       bool   `koanf:"enabled"`
-	JWKSEndpoint  string `koanf:"jwks_endpoint"  validate:"required_if=Enabled true,omitempty,url"`
-	Issuer        string `koanf:"issuer"         validate:"required_if=Enabled true"`
-	Audience      string `koanf:"audience"       validate:"required_if=Enabled true"`
-	ClaimsHeader  string `koanf:"claims_header"`
-	RolesHeader   string `koanf:"roles_header"`
-	ScopesHeader  string `koanf:"scopes_header"`
-	SubjectHeader string `koanf:"subject_header"`
+	Enabled       bool   `koanf:"enabled"                                                          desc:"Enable JWT validation"`
+	JWKSEndpoint  string `koanf:"jwks_endpoint"  validate:"required_if=Enabled true,omitempty,url" desc:"JWKS URL for token signature keys"`
+	Issuer        string `koanf:"issuer"         validate:"required_if=Enabled true"               desc:"Expected token issuer"`
+	Audience      string `koanf:"audience"       validate:"required_if=Enabled true"               desc:"Expected token audience"`
+	ClaimsHeader  string `koanf:"claims_header"                                                    desc:"Header carrying the full claims from the gateway"`
+	RolesHeader   string `koanf:"roles_header"                                                     desc:"Header carrying comma-separated roles"`
+	ScopesHeader  string `koanf:"scopes_header"                                                    desc:"Header carrying space-separated scopes"`
+	SubjectHeader string `koanf:"subject_header"                                                   desc:"Header carrying the subject (user ID)"`
+}
+
+// AuditConfig contains audit trail settings. The audit file is separate
+// from the application log file and has its own retention.
+type AuditConfig struct {
+	Enabled    bool   `koanf:"enabled"                                         desc:"Record authorization decisions and commits to the audit file"`
+	Path       string `koanf:"path"        validate:"required_if=Enabled true" desc:"Audit file path"`
+	MaxSizeMB  int    `koanf:"max_size"    validate:"omitempty,min=1,max=1024" desc:"Maximum file size in megabytes before rotation"`
+	MaxBackups int    `koanf:"max_backups" validate:"omitempty,min=0"          desc:"Number of rotated files to keep; 0 keeps all"`
+	MaxAgeDays int    `koanf:"max_age"     validate:"omitempty,min=0,max=3650" desc:"Days to keep rotated files; 0 keeps them forever"`
+	Compress   bool   `koanf:"compress"                                        desc:"Gzip rotated files"`
 }
 
 // ClientConfig contains HTTP client settings for downstream services.
 type ClientConfig struct {
-	Timeout        time.Duration        `koanf:"timeout"         validate:"required,min=100ms"`
-	Retry          RetryConfig          `koanf:"retry"           validate:"required"`
-	CircuitBreaker CircuitBreakerConfig `koanf:"circuit_breaker" validate:"required"`
-	Transport      TransportConfig      `koanf:"transport"       validate:"required"`
+	Timeout        time.Duration        `koanf:"timeout"         validate:"required,min=100ms" desc:"Per-request timeout"`
+	Retry          RetryConfig          `koanf:"retry"           validate:"required"           desc:"Retry with exponential backoff"`
+	CircuitBreaker CircuitBreakerConfig `koanf:"circuit_breaker" validate:"required"           desc:"Circuit breaker"`
+	Transport      TransportConfig      `koanf:"transport"       validate:"required"           desc:"Connection pool"`
 }
 
 // RetryConfig contains retry settings for HTTP clients.
 type RetryConfig struct {
-	MaxAttempts     int           `koanf:"max_attempts"     validate:"required,min=1,max=10"`
-	InitialInterval time.Duration `koanf:"initial_interval" validate:"required,min=10ms"`
-	MaxInterval     time.Duration `koanf:"max_interval"     validate:"required,min=100ms"`
-	Multiplier      float64       `koanf:"multiplier"       validate:"required,min=1.1,max=10"`
-	JitterFactor    float64       `koanf:"jitter_factor"    validate:"min=0,max=1"`
+	MaxAttempts     int           `koanf:"max_attempts"     validate:"required,min=1,max=10"   desc:"Total attempts including the first"`
+	InitialInterval time.Duration `koanf:"initial_interval" validate:"required,min=10ms"       desc:"Backoff before the first retry"`
+	MaxInterval     time.Duration `koanf:"max_interval"     validate:"required,min=100ms"      desc:"Upper bound on backoff"`
+	Multiplier      float64       `koanf:"multiplier"       validate:"required,min=1.1,max=10" desc:"Backoff growth factor"`
+	JitterFactor    float64       `koanf:"jitter_factor"    validate:"min=0,max=1"             desc:"Random jitter as a fraction of the backoff"`
 }
 
 // CircuitBreakerConfig contains circuit breaker settings for HTTP clients.
 type CircuitBreakerConfig struct {
-	MaxFailures   int           `koanf:"max_failures"    validate:"required,min=1"`
-	Timeout       time.Duration `koanf:"timeout"         validate:"required,min=1s"`
-	HalfOpenLimit int           `koanf:"half_open_limit" validate:"required,min=1"`
+	MaxFailures   int           `koanf:"max_failures"    validate:"required,min=1"  desc:"Consecutive failures before the circuit opens"`
+	Timeout       time.Duration `koanf:"timeout"         validate:"required,min=1s" desc:"Time the circuit stays open before probing"`
+	HalfOpenLimit int           `koanf:"half_open_limit" validate:"required,min=1"  desc:"Successful probes needed to close the circuit"`
 }
 
 // TransportConfig contains HTTP transport pool settings.
 type TransportConfig struct {
-	MaxIdleConns        int           `koanf:"max_idle_conns"         validate:"required,min=1"`
-	MaxIdleConnsPerHost int           `koanf:"max_idle_conns_per_host" validate:"required,min=1"`
-	IdleConnTimeout     time.Duration `koanf:"idle_conn_timeout"      validate:"required,min=1s"`
+	MaxIdleConns        int           `koanf:"max_idle_conns"          validate:"required,min=1"  desc:"Maximum idle connections across all hosts"`
+	MaxIdleConnsPerHost int           `koanf:"max_idle_conns_per_host" validate:"required,min=1"  desc:"Maximum idle connections per host"`
+	IdleConnTimeout     time.Duration `koanf:"idle_conn_timeout"       validate:"required,min=1s" desc:"How long idle connections are kept"`
 }
 
-// ServicesConfig contains configuration for downstream services.
-type ServicesConfig struct {
-	Quote ServiceEndpointConfig `koanf:"quote" validate:"required"`
-}
+// ServicesConfig maps downstream service keys (e.g. "quote") to endpoint settings.
+type ServicesConfig map[string]ServiceEndpointConfig
 
 // ServiceEndpointConfig contains configuration for a downstream service endpoint.
+// Timeout, retry, circuit breaker and transport settings that a service does not
+// set are inherited from the global client section at load time.
 type ServiceEndpointConfig struct {
-	BaseURL string `koanf:"base_url" validate:"required,url"`
-	Name    string `koanf:"name"     validate:"required"`
+	BaseURL        string               `koanf:"base_url"        validate:"required,url"       desc:"Base URL of the service"`
+	Name           string               `koanf:"name"            validate:"required"           desc:"Name used in logs, metrics and health checks (defaults to the service key)"`
+	HealthPath     string               `koanf:"health_path"                                   desc:"Path probed by the readiness check; if empty, only the circuit breaker state is reported"`
+	Timeout        time.Duration        `koanf:"timeout"         validate:"required,min=100ms" desc:"Per-request timeout (inherits client.timeout)"`
+	Retry          RetryConfig          `koanf:"retry"           validate:"required"           desc:"Retry with exponential backoff (inherits client.retry)"`
+	CircuitBreaker CircuitBreakerConfig `koanf:"circuit_breaker" validate:"required"           desc:"Circuit breaker (inherits client.circuit_breaker)"`
+	Transport      TransportConfig      `koanf:"transport"       validate:"required"           desc:"Connection pool (inherits client.transport)"`
+}
+
+// RemoteConfig configures the remote configuration source. It is read from
+// defaults, config files and env vars, and cannot be set remotely.
+type RemoteConfig struct {
+	Enabled      bool          `koanf:"enabled"                                                         desc:"Load a config layer from the remote source"`
+	URL          string        `koanf:"url"           validate:"required_if=Enabled true,omitempty,url" desc:"Document URL: http(s):// endpoint returning YAML or JSON, or file:// stand-in"`
+	Path         string        `koanf:"path"                                                            desc:"Dotted key of the subtree to load from the document; empty loads it all"`
+	Timeout      time.Duration `koanf:"timeout"       validate:"omitempty,min=100ms"                    desc:"Timeout for each fetch"`
+	PollInterval time.Duration `koanf:"poll_interval" validate:"omitempty,min=1s"                       desc:"How often to poll for changes (ETag); 0 disables polling"`
 }
 
 // defaults returns the default configuration values.
@@ -189,10 +378,52 @@ func defaults() map[string]any {
 		"log.file.max_age":     DefaultLogFileMaxAgeDays,
 		"log.file.compress":    true,
 
-		"telemetry.enabled":       false,
-		"telemetry.endpoint":      "",
-		"telemetry.service_name":  "go-service-template",
-		"telemetry.sampling_rate": 1.0,
+		"log.sampling.enabled":    false,
+		"log.sampling.window":     "1s",
+		"log.sampling.first":      DefaultLogSamplingFirst,
+		"log.sampling.thereafter": DefaultLogSamplingThereafter,
+		"log.sampling.dedup":      true,
+
+		"log.buffer.enabled": false,
+		"log.buffer.size":    DefaultLogBufferSize,
+
+		"log.async.enabled":    false,
+		"log.async.queue_size": DefaultLogAsyncQueueSize,
+		"log.async.policy":     "block",
+
+		"log.request_debug.enabled":          false,
+		"log.request_debug.header":           "X-Debug-Log",
+		"log.request_debug.allowed_subjects": []string{},
+		"log.request_debug.allowed_roles":    []string{"admin"},
+
+		"log.redact.fields":    []string{},
+		"log.redact.patterns":  []string{},
+		"log.redact.detectors": []string{"email", "card", "iban"},
+		"log.redact.style":     "full",
+		"log.redact.hash_key":  "",
+
+		"log.headers": []string{},
+
+		"telemetry.enabled":              false,
+		"telemetry.exporter":             "otlp-grpc",
+		"telemetry.endpoint":             "",
+		"telemetry.service_name":         "go-service-template",
+		"telemetry.sampling_rate":        1.0,
+		"telemetry.sampling.routes":      map[string]float64{"/-/": 0},
+		"telemetry.sampling.rate_limit":  0,
+		"telemetry.sampling.keep_errors": false,
+		"telemetry.logs":                 false,
+		"telemetry.metrics_interval":     "60s",
+		"telemetry.prometheus":           true,
+		"telemetry.tls.min_version":      "1.2",
+		"telemetry.headers":              map[string]string{},
+		"telemetry.bearer_token":         "",
+		"telemetry.otlp.compression":     "none",
+		"telemetry.otlp.timeout":         "10s",
+		"telemetry.stdout.pretty":        true,
+		"telemetry.stdout.metrics":       false,
+		"telemetry.file.path":            "./logs/telemetry.json",
+		"telemetry.file.pretty":          false,
 
 		"auth.enabled":        false,
 		"auth.jwks_endpoint":  "",
@@ -203,6 +434,13 @@ func defaults() map[string]any {
 		"auth.scopes_header":  "X-User-Scopes",
 		"auth.subject_header": "X-User-ID",
 
+		"audit.enabled":     false,
+		"audit.path":        "./logs/audit.log",
+		"audit.max_size":    DefaultAuditMaxSizeMB,
+		"audit.max_backups": 0,
+		"audit.max_age":     DefaultAuditMaxAgeDays,
+		"audit.compress":    true,
+
 		"client.timeout":                           "30s",
 		"client.retry.max_attempts":                DefaultClientRetryMaxAttempts,
 		"client.retry.initial_interval":            "100ms",
@@ -216,55 +454,110 @@ func defaults() map[string]any {
 		"client.transport.max_idle_conns_per_host": DefaultTransportMaxIdleConnsPerHost,
 		"client.transport.idle_conn_timeout":       "90s",
 
-		"services.quote.base_url": "https://api.quotable.io",
-		"services.quote.name":     "quote-service",
+		"services.quote.base_url":    "https://api.quotable.io",
+		"services.quote.name":        "quote-service",
+		"services.quote.health_path": "/random",
+
+		"remote.enabled":       false,
+		"remote.timeout":       "5s",
+		"remote.poll_interval": "30s",
 	}
 }
 
 // Load loads configuration with the following precedence (highest to lowest):
 //  1. Environment variables (APP_ prefix)
-//  2. Profile config file (configs/{profile}.yaml)
-//  3. Base config file (configs/base.yaml)
-//  4. Default values
+//  2. Remote source (remote.url), if remote.enabled
+//  3. Profile drop-ins (configs/{profile}.d/*.yaml), later names winning
+//  4. Profile config file (configs/{profile}.yaml)
+//  5. Base config file (configs/base.yaml)
+//  6. Default values
+//
+// Any config file may list other files under include:, resolved relative to
+// its own directory. Included files are merged just beneath the including
+// file; cycles fail with ErrIncludeCycle and missing files with
+// ErrIncludeNotFound.
+//
+// Keys that do not map to a Config field fail the load with ErrUnknownKeys in
+// the prod and qa profiles; elsewhere they are reported by Config.UnknownKeys.
+//
+// After all layers are merged, each services.<name> entry inherits any timeout,
+// retry, circuit_breaker or transport setting it does not set from client.*,
+// and string values that reference a registered
+// SecretResolver scheme (e.g. "file:///run/secrets/x", "env:OTHER_VAR") are
+// replaced with the resolved secret.
 func Load(profile string) (*Config, error) {
 	k := koanf.New(".")
+	origins := make(map[string]Origin)
 
 	// 1. Load defaults
-	err := k.Load(confmap.Provider(defaults(), "."), nil)
+	err := loadLayer(k, origins, Origin{Source: SourceDefaults}, confmap.Provider(defaults(), "."), nil)
 	if err != nil {
 		return nil, fmt.Errorf("loading defaults: %w", err)
 	}
 
-	// 2. Load base config file if it exists
-	err = loadFileIfExists(k, "configs/base.yaml")
-	if err != nil {
-		return nil, fmt.Errorf("loading base config: %w", err)
+	// 2. Load base, profile and drop-in files that exist, each after its includes
+	for _, src := range profileSources(profile) {
+		if err := loadConfigFile(k, origins, src.Source, src.Detail); err != nil {
+			return nil, fmt.Errorf("loading %s config: %w", src.Source, err)
+		}
 	}
 
-	// 3. Load profile config file if it exists
-	if profile != "" {
-		profilePath := fmt.Sprintf("configs/%s.yaml", profile)
+	// Read environment variables with APP_ prefix now; they are merged last,
+	// but may configure the remote source.
+	envNames := make(map[string]string)
+	envLayer := koanf.New(".")
 
-		err := loadFileIfExists(k, profilePath)
-		if err != nil {
-			return nil, fmt.Errorf("loading profile config %q: %w", profile, err)
-		}
-	}
+	err = envLayer.Load(env.Provider("APP_", ".", func(s string) string {
+		key := envKey(s)
+		envNames[key] = s
 
-	// 4. Load environment variables with APP_ prefix
-	err = k.Load(env.Provider("APP_", ".", func(s string) string {
-		return strings.ReplaceAll(
-			strings.ToLower(strings.TrimPrefix(s, "APP_")),
-			"_",
-			".",
-		)
+		return key
 	}), nil)
 	if err != nil {
 		return nil, fmt.Errorf("loading env vars: %w", err)
 	}
 
+	// 3. Load the remote source, if enabled
+	remoteVersion, err := loadRemote(k, envLayer, origins)
+	if err != nil {
+		return nil, fmt.Errorf("loading remote config: %w", err)
+	}
+
+	// 4. Merge environment variables
+	err = mergeLayer(k, origins, envLayer, func(key string) Origin {
+		return Origin{Source: SourceEnv, Detail: envNames[key]}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("merging env vars: %w", err)
+	}
+
+	// 5. Detect keys that do not map to any Config field (fatal in strict profiles)
+	unknown := findUnknownKeys(k.Keys(), k.All(), origins)
+	if len(unknown) > 0 && strictProfiles[profile] {
+		return nil, unknownKeysError(unknown)
+	}
+
+	// 6. Fill per-service client settings from the global client section
+	err = inheritClientSettings(k, origins)
+	if err != nil {
+		return nil, fmt.Errorf("applying service client settings: %w", err)
+	}
+
+	// 7. Resolve secret references
+	secrets, err := resolveSecrets(k)
+	if err != nil {
+		return nil, fmt.Errorf("resolving secrets: %w", err)
+	}
+
 	// Unmarshal into Config struct
-	var cfg Config
+	cfg := Config{
+		secrets: secrets,
+		origins: origins,
+		values:  k.All(),
+		unknown: unknown,
+
+		remoteVersion: remoteVersion,
+	}
 
 	err = k.Unmarshal("", &cfg)
 	if err != nil {
@@ -274,12 +567,70 @@ func Load(profile string) (*Config, error) {
 	return &cfg, nil
 }
 
-// loadFileIfExists loads a YAML config file if it exists.
-// Returns nil if the file doesn't exist, error only for parse/read failures.
-func loadFileIfExists(k *koanf.Koanf, path string) error {
-	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
-		return nil // File doesn't exist, that's fine
+// loadLayer loads a provider into its own koanf instance, records origin for
+// every key it supplies, and merges it into k.
+func loadLayer(k *koanf.Koanf, origins map[string]Origin, origin Origin, p koanf.Provider, pa koanf.Parser) error {
+	layer := koanf.New(".")
+
+	if err := layer.Load(p, pa); err != nil {
+		return err
+	}
+
+	return mergeLayer(k, origins, layer, func(string) Origin { return origin })
+}
+
+// mergeLayer records the origin of every key in layer and merges it into k.
+func mergeLayer(k *koanf.Koanf, origins map[string]Origin, layer *koanf.Koanf, origin func(key string) Origin) error {
+	for _, key := range layer.Keys() {
+		origins[key] = origin(key)
+	}
+
+	return k.Merge(layer)
+}
+
+// profileConfigPath returns the path of the config file for a profile.
+func profileConfigPath(profile string) string {
+	return fmt.Sprintf("%s/%s.yaml", configDir, profile)
+}
+
+// configFiles returns the config files that contribute to a profile, in load
+// order, including drop-ins and included files. Files that do not exist are
+// omitted; include errors are returned as Load would report them.
+func configFiles(profile string) ([]string, error) {
+	var files []string
+
+	for _, src := range profileSources(profile) {
+		err := walkConfigFile(src.Detail, nil, func(path string, _ *koanf.Koanf) error {
+			if !slices.Contains(files, path) {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// Profiles returns the names of all profiles with a config file in the
+// configs directory, sorted. The shared base file is not a profile.
+func Profiles() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(configDir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing profiles: %w", err)
+	}
+
+	profiles := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if filepath.ToSlash(path) == baseConfigPath {
+			continue
+		}
+
+		profiles = append(profiles, strings.TrimSuffix(filepath.Base(path), ".yaml"))
 	}
 
-	return k.Load(file.Provider(path), yaml.Parser())
+	return profiles, nil
 }
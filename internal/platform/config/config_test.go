@@ -99,6 +99,24 @@ func TestLoad_LogFileDefaults(t *testing.T) {
 	assert.Equal(t, DefaultLogFileMaxBackups, cfg.Log.File.MaxBackups)
 	assert.Equal(t, DefaultLogFileMaxAgeDays, cfg.Log.File.MaxAgeDays)
 	assert.True(t, cfg.Log.File.Compress)
+
+	// Check async defaults
+	assert.False(t, cfg.Log.Async.Enabled)
+	assert.Equal(t, DefaultLogAsyncQueueSize, cfg.Log.Async.QueueSize)
+	assert.Equal(t, "block", cfg.Log.Async.Policy)
+}
+
+// TestLoad_AuditDefaults tests that audit defaults are set correctly.
+func TestLoad_AuditDefaults(t *testing.T) {
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.False(t, cfg.Audit.Enabled)
+	assert.Equal(t, "./logs/audit.log", cfg.Audit.Path)
+	assert.Equal(t, DefaultAuditMaxSizeMB, cfg.Audit.MaxSizeMB)
+	assert.Zero(t, cfg.Audit.MaxBackups)
+	assert.Equal(t, DefaultAuditMaxAgeDays, cfg.Audit.MaxAgeDays)
+	assert.True(t, cfg.Audit.Compress)
 }
 
 // TestLoad_TelemetryDefaults tests that telemetry defaults are set correctly.
@@ -109,6 +127,7 @@ func TestLoad_TelemetryDefaults(t *testing.T) {
 	assert.False(t, cfg.Telemetry.Enabled)
 	assert.Equal(t, "go-service-template", cfg.Telemetry.ServiceName)
 	assert.InEpsilon(t, 1.0, cfg.Telemetry.SamplingRate, 0.0001)
+	assert.Equal(t, map[string]float64{"/-/": 0}, cfg.Telemetry.Sampling.Routes)
 }
 
 // TestLoad_ClientDefaults tests that HTTP client defaults are set correctly.
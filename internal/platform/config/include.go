@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+)
+
+// includeKey is the top-level key listing files merged beneath a config file.
+const includeKey = "include"
+
+var (
+	// ErrIncludeCycle is returned when config files include each other.
+	ErrIncludeCycle = errors.New("include cycle")
+
+	// ErrIncludeNotFound is returned when an included config file does not exist.
+	ErrIncludeNotFound = errors.New("included file not found")
+)
+
+// profileSources returns the config files that form a profile, in load order:
+// the base file, the profile file, then the profile's drop-in directory
+// (configs/{profile}.d/*.yaml) in lexical order. Files that do not exist are
+// omitted; includes are not expanded.
+func profileSources(profile string) []Origin {
+	var sources []Origin
+
+	if fileExists(baseConfigPath) {
+		sources = append(sources, Origin{Source: SourceBase, Detail: baseConfigPath})
+	}
+
+	if profile == "" {
+		return sources
+	}
+
+	if p := profileConfigPath(profile); fileExists(p) {
+		sources = append(sources, Origin{Source: SourceProfile, Detail: p})
+	}
+
+	// Glob sorts matches, which gives the documented lexical merge order
+	dropIns, _ := filepath.Glob(filepath.Join(dropInDir(profile), "*.yaml"))
+	for _, p := range dropIns {
+		sources = append(sources, Origin{Source: SourceProfile, Detail: filepath.ToSlash(p)})
+	}
+
+	return sources
+}
+
+// dropInDir returns the drop-in directory for a profile.
+func dropInDir(profile string) string {
+	return fmt.Sprintf("%s/%s.d", configDir, profile)
+}
+
+// loadConfigFile merges a config file and everything it includes into k.
+// Included files are merged first, so the including file overrides them.
+func loadConfigFile(k *koanf.Koanf, origins map[string]Origin, source Source, p string) error {
+	return walkConfigFile(p, nil, func(p string, layer *koanf.Koanf) error {
+		origin := Origin{Source: source, Detail: p}
+
+		return mergeLayer(k, origins, layer, func(string) Origin { return origin })
+	})
+}
+
+// walkConfigFile parses p, walks its includes depth-first and then calls visit
+// with p and its contents (minus the include key). stack is the chain of
+// files currently being walked, used to report cycles.
+func walkConfigFile(p string, stack []string, visit func(p string, layer *koanf.Koanf) error) error {
+	stack = append(stack, p)
+
+	if slices.Contains(stack[:len(stack)-1], p) {
+		return fmt.Errorf("%w: %s", ErrIncludeCycle, strings.Join(stack, " -> "))
+	}
+
+	layer := koanf.New(".")
+	if err := layer.Load(file.Provider(p), yaml.Parser()); err != nil {
+		return fmt.Errorf("loading %s: %w", p, err)
+	}
+
+	includes, err := includesOf(p, layer)
+	if err != nil {
+		return err
+	}
+
+	layer.Delete(includeKey)
+
+	for _, inc := range includes {
+		if !fileExists(inc) {
+			return fmt.Errorf("%w: %s (included from %s)", ErrIncludeNotFound, inc, p)
+		}
+
+		if err := walkConfigFile(inc, stack, visit); err != nil {
+			return err
+		}
+	}
+
+	return visit(p, layer)
+}
+
+// includesOf returns the files listed under the include key of a config file,
+// resolved relative to the directory of that file.
+func includesOf(p string, layer *koanf.Koanf) ([]string, error) {
+	if !layer.Exists(includeKey) {
+		return nil, nil
+	}
+
+	list, ok := layer.Get(includeKey).([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s must be a list of file paths", p, includeKey)
+	}
+
+	includes := make([]string, 0, len(list))
+
+	for _, item := range list {
+		name, ok := item.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s: %s must be a list of file paths", p, includeKey)
+		}
+
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(filepath.Dir(p), name)
+		}
+
+		includes = append(includes, filepath.ToSlash(name))
+	}
+
+	return includes, nil
+}
+
+// fileExists reports whether p exists.
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
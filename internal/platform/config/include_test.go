@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a file at a path relative to the configs directory under dir.
+func writeConfigFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, configDir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// TestLoad_Include_MergesBeneathIncludingFile tests that the including file overrides its includes.
+func TestLoad_Include_MergesBeneathIncludingFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeConfigFile(t, dir, "shared/timeouts.yaml", "server:\n  read_timeout: 15s\n  idle_timeout: 60s\n")
+	writeProfile(t, dir, "prod", "include:\n  - shared/timeouts.yaml\nserver:\n  idle_timeout: 90s\n")
+
+	cfg, err := Load("prod")
+	require.NoError(t, err)
+
+	assert.Equal(t, 15*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 90*time.Second, cfg.Server.IdleTimeout)
+	assert.Empty(t, cfg.UnknownKeys(), "include must not be reported as an unknown key")
+
+	origin, _ := cfg.Origin("server.read_timeout")
+	assert.Equal(t, Origin{Source: SourceProfile, Detail: "configs/shared/timeouts.yaml"}, origin)
+
+	origin, _ = cfg.Origin("server.idle_timeout")
+	assert.Equal(t, Origin{Source: SourceProfile, Detail: "configs/prod.yaml"}, origin)
+}
+
+// TestLoad_DropInDir_MergedInLexicalOrder tests that drop-ins override the profile file in name order.
+func TestLoad_DropInDir_MergedInLexicalOrder(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "dev", "log:\n  level: info\n  format: json\n")
+	writeConfigFile(t, dir, "dev.d/20-debug.yaml", "log:\n  level: debug\n")
+	writeConfigFile(t, dir, "dev.d/10-warn.yaml", "log:\n  level: warn\n  format: text\n")
+	writeConfigFile(t, dir, "dev.d/README.md", "not config")
+
+	cfg, err := Load("dev")
+	require.NoError(t, err)
+
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, "text", cfg.Log.Format)
+
+	origin, _ := cfg.Origin("log.level")
+	assert.Equal(t, "configs/dev.d/20-debug.yaml", origin.Detail)
+
+	files, err := configFiles("dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"configs/dev.yaml", "configs/dev.d/10-warn.yaml", "configs/dev.d/20-debug.yaml"}, files)
+}
+
+// TestLoad_Include_ReportsCycle tests that files including each other fail with the full chain.
+func TestLoad_Include_ReportsCycle(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "qa", "include:\n  - shared/a.yaml\n")
+	writeConfigFile(t, dir, "shared/a.yaml", "include:\n  - b.yaml\n")
+	writeConfigFile(t, dir, "shared/b.yaml", "include:\n  - a.yaml\n")
+
+	_, err := Load("qa")
+	require.ErrorIs(t, err, ErrIncludeCycle)
+	assert.Contains(t, err.Error(),
+		"configs/qa.yaml -> configs/shared/a.yaml -> configs/shared/b.yaml -> configs/shared/a.yaml")
+
+	_, err = configFiles("qa")
+	require.ErrorIs(t, err, ErrIncludeCycle)
+}
+
+// TestLoad_Include_ReportsMissingFile tests that a missing include names the file and its includer.
+func TestLoad_Include_ReportsMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "qa", "include:\n  - shared/missing.yaml\n")
+
+	_, err := Load("qa")
+	require.ErrorIs(t, err, ErrIncludeNotFound)
+	assert.Contains(t, err.Error(), "configs/shared/missing.yaml (included from configs/qa.yaml)")
+}
+
+// TestLoad_Include_RejectsScalar tests that include must be a list.
+func TestLoad_Include_RejectsScalar(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "qa", "include: shared/timeouts.yaml\n")
+
+	_, err := Load("qa")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "include must be a list of file paths")
+}
+
+// TestValidateProfileFiles_ChecksIncludedFiles tests that schema validation covers included files.
+func TestValidateProfileFiles_ChecksIncludedFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "qa", "include:\n  - shared/timeouts.yaml\n")
+	writeConfigFile(t, dir, "shared/timeouts.yaml", "server:\n  read_timeout: soon\n")
+
+	err := ValidateProfileFiles("qa")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "configs/shared/timeouts.yaml does not match schema")
+	assert.Contains(t, err.Error(), "server.read_timeout must be a duration")
+}
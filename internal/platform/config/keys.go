@@ -0,0 +1,314 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownKeys is returned by Load in strict profiles when a config file or
+// environment variable sets a key that does not map to any Config field.
+var ErrUnknownKeys = errors.New("unknown config keys")
+
+// strictProfiles are the profiles in which unknown keys fail Load.
+// Other profiles load normally and report them via Config.UnknownKeys.
+var strictProfiles = map[string]bool{
+	"prod": true,
+	"qa":   true,
+}
+
+// reservedEnvVars select the profile and are not config keys.
+var reservedEnvVars = map[string]bool{
+	"APP_ENVIRONMENT": true,
+	"APP_PROFILE":     true,
+}
+
+// Key pattern segments for map-typed fields.
+const (
+	anySegment = "*"  // Matches one segment, e.g. the service name in services.*.base_url
+	anySuffix  = "**" // Matches any remaining segments, e.g. features.**
+)
+
+// maxSuggestionDistance bounds how different a suggestion may be from the unknown key.
+const maxSuggestionDistance = 3
+
+// UnknownKey is a loaded key that does not map to any Config field.
+type UnknownKey struct {
+	// Key is the dotted config key, e.g. "server.read_timout".
+	Key string
+
+	// Origin is the layer that set the key.
+	Origin Origin
+
+	// Suggestion is the closest known key (or environment variable), if any.
+	Suggestion string
+}
+
+// String formats the key with where it was set and any suggestion, e.g.
+// "server.read_timout in configs/prod.yaml is not a known config key; did you mean server.read_timeout?".
+func (u UnknownKey) String() string {
+	var msg string
+
+	switch {
+	case u.Origin.Source == SourceEnv:
+		msg = "environment variable " + u.Origin.Detail
+	case u.Origin.Detail != "":
+		msg = u.Key + " in " + u.Origin.Detail
+	default:
+		msg = u.Key
+	}
+
+	msg += " is not a known config key"
+	if u.Suggestion != "" {
+		msg += "; did you mean " + u.Suggestion + "?"
+	}
+
+	return msg
+}
+
+// UnknownKeys returns the keys that did not map to any Config field. They are
+// only reported here in non-strict profiles; strict profiles fail Load instead.
+func (c *Config) UnknownKeys() []UnknownKey {
+	return c.unknown
+}
+
+// knownKeyPatterns returns the dotted key patterns for every Config field,
+// computed once from the koanf tags.
+var knownKeyPatterns = sync.OnceValue(func() [][]string {
+	var patterns [][]string
+
+	collectKeyPatterns(reflect.TypeFor[Config](), nil, &patterns)
+
+	return patterns
+})
+
+// collectKeyPatterns appends the key patterns for t under prefix.
+func collectKeyPatterns(t reflect.Type, prefix []string, patterns *[][]string) {
+	switch {
+	case t == durationType:
+		*patterns = append(*patterns, prefix)
+	case t.Kind() == reflect.Struct:
+		for field := range fieldsOf(t) {
+			collectKeyPatterns(field.Type, append(slices.Clip(prefix), field.Tag.Get("koanf")), patterns)
+		}
+	case t.Kind() == reflect.Map && t.Elem().Kind() == reflect.Interface:
+		*patterns = append(*patterns, append(slices.Clip(prefix), anySuffix))
+	case t.Kind() == reflect.Map:
+		collectKeyPatterns(t.Elem(), append(slices.Clip(prefix), anySegment), patterns)
+	default:
+		*patterns = append(*patterns, prefix)
+	}
+}
+
+// matchPattern reports whether key segments match a pattern.
+func matchPattern(pattern, segments []string) bool {
+	for i, p := range pattern {
+		if p == anySuffix {
+			return true
+		}
+
+		if i >= len(segments) || (p != anySegment && p != segments[i]) {
+			return false
+		}
+	}
+
+	return len(pattern) == len(segments)
+}
+
+// isKnownKey reports whether key maps to a Config field. A map such as
+// "features" or "telemetry.headers" is known even when empty.
+func isKnownKey(key string) bool {
+	segments := strings.Split(key, ".")
+
+	for _, pattern := range knownKeyPatterns() {
+		if matchPattern(pattern, segments) {
+			return true
+		}
+
+		if n := len(segments); n < len(pattern) && isWildcard(pattern[n]) && matchPattern(pattern[:n], segments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWildcard reports whether a pattern segment matches map keys.
+func isWildcard(segment string) bool {
+	return segment == anySegment || segment == anySuffix
+}
+
+// findUnknownKeys returns the keys of values that do not map to a Config
+// field, with origins and suggestions.
+func findUnknownKeys(keys []string, values map[string]any, origins map[string]Origin) []UnknownKey {
+	var unknown []UnknownKey
+
+	for _, key := range keys {
+		if isKnownKey(key) {
+			continue
+		}
+
+		// An empty mapping such as `services: {}` flattens to a single key.
+		if m, ok := values[key].(map[string]any); ok && len(m) == 0 {
+			continue
+		}
+
+		origin := origins[key]
+		unknown = append(unknown, UnknownKey{
+			Key:        key,
+			Origin:     origin,
+			Suggestion: suggestKey(key, origin),
+		})
+	}
+
+	return unknown
+}
+
+// unknownKeysError formats unknown keys as a single error wrapping ErrUnknownKeys.
+func unknownKeysError(unknown []UnknownKey) error {
+	lines := make([]string, 0, len(unknown))
+	for _, u := range unknown {
+		lines = append(lines, u.String())
+	}
+
+	return fmt.Errorf("%w:\n  %s", ErrUnknownKeys, strings.Join(lines, "\n  "))
+}
+
+// suggestKey returns the known key closest to key, formatted as an
+// environment variable when the unknown key came from one.
+func suggestKey(key string, origin Origin) string {
+	segments := strings.Split(key, ".")
+	best, bestDistance := "", maxSuggestionDistance+1
+
+	for _, pattern := range knownKeyPatterns() {
+		candidate := instantiatePattern(pattern, segments)
+		if candidate == "" {
+			continue
+		}
+
+		if d := levenshtein(key, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	if best != "" && origin.Source == SourceEnv {
+		return envVarName(best)
+	}
+
+	return best
+}
+
+// instantiatePattern fills wildcard segments from the unknown key so that
+// "services.*.base_url" is compared as "services.payments.base_url".
+// Returns "" for open patterns, which cannot be misspelled.
+func instantiatePattern(pattern, segments []string) string {
+	parts := make([]string, len(pattern))
+
+	for i, p := range pattern {
+		switch {
+		case p == anySuffix:
+			return ""
+		case p == anySegment && i < len(segments):
+			parts[i] = segments[i]
+		default:
+			parts[i] = p
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// envVarName returns the environment variable that sets key.
+func envVarName(key string) string {
+	return "APP_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// envKey maps an APP_ environment variable to a config key. Because config
+// keys themselves contain underscores (APP_SERVER_READ_TIMEOUT), the name is
+// matched against known keys; unmatched names fall back to treating every
+// underscore as a separator and are then reported as unknown.
+// Reserved variables map to "" and are skipped.
+func envKey(name string) string {
+	if reservedEnvVars[name] {
+		return ""
+	}
+
+	raw := strings.ToLower(strings.TrimPrefix(name, "APP_"))
+	segments := strings.Split(raw, "_")
+
+	for _, pattern := range knownKeyPatterns() {
+		if key, ok := matchEnvPattern(pattern, segments); ok {
+			return key
+		}
+	}
+
+	return strings.ReplaceAll(raw, "_", ".")
+}
+
+// matchEnvPattern matches underscore-separated segments against a key pattern
+// whose own segments may contain underscores. A single-segment wildcard
+// consumes one underscore-separated word; an open suffix consumes the rest as
+// one key, so APP_FEATURES_DARK_MODE sets features.dark_mode.
+func matchEnvPattern(pattern, segments []string) (string, bool) {
+	if len(pattern) == 0 {
+		return "", len(segments) == 0
+	}
+
+	head := pattern[0]
+
+	switch head {
+	case anySuffix:
+		if len(segments) == 0 {
+			return "", false
+		}
+
+		return strings.Join(segments, "_"), true
+	case anySegment:
+		if len(segments) == 0 {
+			return "", false
+		}
+
+		rest, ok := matchEnvPattern(pattern[1:], segments[1:])
+
+		return joinKey(segments[0], rest), ok
+	}
+
+	words := strings.Split(head, "_")
+	if len(segments) < len(words) || !slices.Equal(segments[:len(words)], words) {
+		return "", false
+	}
+
+	rest, ok := matchEnvPattern(pattern[1:], segments[len(words):])
+
+	return joinKey(head, rest), ok
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad_UnknownKeys_WarnInLocalProfile tests that unknown keys load with suggestions outside strict profiles.
+func TestLoad_UnknownKeys_WarnInLocalProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "local", "server:\n  read_timout: 5s\n")
+	t.Setenv("APP_SERVER_READTIMEOUT", "7s")
+
+	cfg, err := Load("local")
+	require.NoError(t, err)
+
+	unknown := cfg.UnknownKeys()
+	require.Len(t, unknown, 2)
+
+	assert.Equal(t, "server.read_timout", unknown[0].Key)
+	assert.Equal(t, "server.read_timeout", unknown[0].Suggestion)
+	assert.Equal(t,
+		"server.read_timout in configs/local.yaml is not a known config key; did you mean server.read_timeout?",
+		unknown[0].String())
+
+	assert.Equal(t, "server.readtimeout", unknown[1].Key)
+	assert.Equal(t, "APP_SERVER_READ_TIMEOUT", unknown[1].Suggestion)
+	assert.Contains(t, unknown[1].String(), "environment variable APP_SERVER_READTIMEOUT")
+}
+
+// TestLoad_UnknownKeys_FatalInStrictProfiles tests that prod and qa reject unknown keys.
+func TestLoad_UnknownKeys_FatalInStrictProfiles(t *testing.T) {
+	for _, profile := range []string{"prod", "qa"} {
+		t.Run(profile, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			writeProfile(t, dir, profile, "log:\n  levle: warn\n")
+
+			_, err := Load(profile)
+			require.ErrorIs(t, err, ErrUnknownKeys)
+			assert.Contains(t, err.Error(), "log.levle")
+			assert.Contains(t, err.Error(), "did you mean log.level?")
+		})
+	}
+}
+
+// TestLoad_KnownKeysAreNotReported tests that map-typed sections and reserved env vars are accepted.
+func TestLoad_KnownKeysAreNotReported(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "prod", `features:
+  new_checkout: true
+  nested:
+    limit: 3
+services:
+  payments:
+    base_url: https://payments.example.com
+    retry:
+      max_attempts: 1
+`)
+	t.Setenv("APP_ENVIRONMENT", "prod")
+	t.Setenv("APP_PROFILE", "prod")
+
+	cfg, err := Load("prod")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.UnknownKeys())
+}
+
+// TestLoad_EnvKeysWithUnderscores tests that env vars map onto keys containing underscores.
+func TestLoad_EnvKeysWithUnderscores(t *testing.T) {
+	t.Setenv("APP_SERVER_READ_TIMEOUT", "45s")
+	t.Setenv("APP_CLIENT_RETRY_MAX_ATTEMPTS", "5")
+	t.Setenv("APP_SERVICES_QUOTE_BASE_URL", "https://quotes.example.com")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.UnknownKeys())
+
+	assert.Equal(t, "45s", cfg.Server.ReadTimeout.String())
+	assert.Equal(t, 5, cfg.Client.Retry.MaxAttempts)
+	assert.Equal(t, "https://quotes.example.com", cfg.Services["quote"].BaseURL)
+}
+
+// TestEnvKey tests environment variable to config key mapping.
+func TestEnvKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"APP_SERVER_PORT", "server.port"},
+		{"APP_LOG_FILE_MAX_BACKUPS", "log.file.max_backups"},
+		{"APP_SERVICES_PAYMENTS_CIRCUIT_BREAKER_MAX_FAILURES", "services.payments.circuit_breaker.max_failures"},
+		{"APP_FEATURES_DARK_MODE", "features.dark_mode"},
+		{"APP_SERVER_READTIMEOUT", "server.readtimeout"},
+		{"APP_ENVIRONMENT", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, envKey(tt.name))
+		})
+	}
+}
+
+// TestLevenshtein tests edit distance.
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("port", "port"))
+	assert.Equal(t, 1, levenshtein("read_timout", "read_timeout"))
+	assert.Equal(t, 2, levenshtein("levle", "level"))
+	assert.Equal(t, 4, levenshtein("", "port"))
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+)
+
+// redactedValue replaces secret values in inspection output.
+const redactedValue = "[REDACTED]"
+
+// Source identifies the configuration layer that supplied a value.
+type Source string
+
+// Configuration layers, in increasing order of precedence.
+const (
+	SourceDefaults Source = "defaults"
+	SourceBase     Source = "base"
+	SourceProfile  Source = "profile"
+	SourceRemote   Source = "remote"
+	SourceEnv      Source = "env"
+)
+
+// Origin records where an effective configuration value came from.
+type Origin struct {
+	// Source is the layer that supplied the value.
+	Source Source
+
+	// Detail is the file path or environment variable name, if any.
+	Detail string
+}
+
+// String returns the origin as "source" or "source (detail)".
+func (o Origin) String() string {
+	if o.Detail == "" {
+		return string(o.Source)
+	}
+
+	return string(o.Source) + " (" + o.Detail + ")"
+}
+
+// Origin returns the layer that supplied the effective value for key
+// (e.g. "server.port"). Returns false if the key was not loaded.
+func (c *Config) Origin(key string) (Origin, bool) {
+	origin, ok := c.origins[key]
+	return origin, ok
+}
+
+// Keys returns all effective configuration keys, sorted.
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// IsSecret reports whether key holds a secret: a field tagged secret:"true"
+// (such as telemetry.bearer_token or any telemetry.headers entry), or a value
+// resolved from a secret reference.
+func (c *Config) IsSecret(key string) bool {
+	_, ok := c.secrets[key]
+	return ok || isSecretField(key)
+}
+
+// RedactedValues returns the flattened effective configuration keyed by
+// config key, with secret values (see IsSecret) replaced by "[REDACTED]".
+// Empty secret fields are kept so operators can see they are unset. It is
+// safe to print or expose to operators.
+func (c *Config) RedactedValues() map[string]any {
+	values := make(map[string]any, len(c.values))
+	for key, value := range c.values {
+		if c.IsSecret(key) && !isEmptyValue(value) {
+			value = redactedValue
+		}
+
+		values[key] = value
+	}
+
+	return values
+}
+
+// isEmptyValue reports whether value is nil, an empty string or an empty map,
+// such as an unset bearer token or headers map.
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]any:
+		return len(v) == 0
+	case map[string]string:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// RedactedTree returns the effective configuration as nested maps, with
+// secrets redacted as in RedactedValues. If replace is non-nil, every value is
+// also passed through it as an slog attribute named after the last segment of
+// its key (the preceding segments are the groups), so log redaction rules such
+// as logging.NewReplaceAttr apply to configuration values too.
+func (c *Config) RedactedTree(replace func(groups []string, a slog.Attr) slog.Attr) (map[string]any, error) {
+	values := c.RedactedValues()
+
+	if replace != nil {
+		for key, value := range values {
+			groups := strings.Split(key, ".")
+			name := groups[len(groups)-1]
+
+			values[key] = replace(groups[:len(groups)-1], slog.Any(name, value)).Value.Any()
+		}
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(values, "."), nil); err != nil {
+		return nil, fmt.Errorf("loading values: %w", err)
+	}
+
+	return k.Raw(), nil
+}
+
+// RedactedYAML renders the effective configuration as YAML with secrets
+// redacted, as shown by `service config show`.
+func (c *Config) RedactedYAML() ([]byte, error) {
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(c.RedactedValues(), "."), nil); err != nil {
+		return nil, fmt.Errorf("loading values: %w", err)
+	}
+
+	out, err := k.Marshal(yaml.Parser())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling yaml: %w", err)
+	}
+
+	return out, nil
+}
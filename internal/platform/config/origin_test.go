@@ -0,0 +1,104 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad_RecordsOrigins tests that each key records the layer that supplied it.
+func TestLoad_RecordsOrigins(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "base", "server:\n  host: 127.0.0.1\n")
+	writeProfile(t, dir, "test", "log:\n  level: debug\n")
+	t.Setenv("APP_SERVER_PORT", "9090")
+
+	cfg, err := Load("test")
+	require.NoError(t, err)
+
+	tests := []struct {
+		key  string
+		want Origin
+	}{
+		{key: "server.shutdown_timeout", want: Origin{Source: SourceDefaults}},
+		{key: "server.host", want: Origin{Source: SourceBase, Detail: "configs/base.yaml"}},
+		{key: "log.level", want: Origin{Source: SourceProfile, Detail: "configs/test.yaml"}},
+		{key: "server.port", want: Origin{Source: SourceEnv, Detail: "APP_SERVER_PORT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := cfg.Origin(tt.key)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, ok := cfg.Origin("server.nope")
+	assert.False(t, ok)
+}
+
+// TestOrigin_String tests origin formatting with and without detail.
+func TestOrigin_String(t *testing.T) {
+	assert.Equal(t, "defaults", Origin{Source: SourceDefaults}.String())
+	assert.Equal(t, "env (APP_SERVER_PORT)", Origin{Source: SourceEnv, Detail: "APP_SERVER_PORT"}.String())
+}
+
+// TestConfig_RedactedYAML tests that resolved secrets never appear in rendered config.
+func TestConfig_RedactedYAML(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "audience")
+	require.NoError(t, os.WriteFile(secretPath, []byte("s3cr3t-audience"), 0o600))
+	t.Setenv("APP_AUTH_AUDIENCE", "file://"+secretPath)
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "[REDACTED]", cfg.RedactedValues()["auth.audience"])
+
+	out, err := cfg.RedactedYAML()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "s3cr3t-audience")
+	assert.Contains(t, string(out), "audience: '[REDACTED]'")
+}
+
+// TestConfig_RedactedTree tests that values are nested and passed through the replace function.
+func TestConfig_RedactedTree(t *testing.T) {
+	t.Setenv("APP_FEATURES_API_KEY", "k-123")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	var groups []string
+
+	tree, err := cfg.RedactedTree(func(g []string, a slog.Attr) slog.Attr {
+		if a.Key == "api_key" {
+			groups = g
+			return slog.String(a.Key, "masked")
+		}
+
+		return a
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"features"}, groups)
+	assert.Equal(t, "masked", tree["features"].(map[string]any)["api_key"])
+	assert.Equal(t, int64(8080), tree["server"].(map[string]any)["port"])
+}
+
+// TestProfiles tests that profiles are listed from the configs directory, excluding base.
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "base", "{}\n")
+	writeProfile(t, dir, "prod", "{}\n")
+	writeProfile(t, dir, "local", "{}\n")
+
+	profiles, err := Profiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"local", "prod"}, profiles)
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeStandInKey writes a document for key into a stand-in directory.
+func writeStandInKey(t *testing.T, dir, file, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+}
+
+// TestLoad_RemoteLayerPrecedence tests that remote sits above the profile file and below env vars.
+func TestLoad_RemoteLayerPrecedence(t *testing.T) {
+	kv := t.TempDir()
+	writeStandInKey(t, kv, "go-service-template.yaml", `prod:
+  log:
+    level: debug
+  server:
+    port: 9000
+    host: 127.0.0.1
+  remote:
+    enabled: false
+`)
+
+	server := httptest.NewServer(NewRemoteStandIn(kv))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", `log:
+  level: warn
+remote:
+  enabled: true
+  path: prod
+  url: `+server.URL+`/v1/kv/go-service-template
+`)
+	t.Setenv("APP_SERVER_PORT", "9100")
+
+	cfg, err := Load("test")
+	require.NoError(t, err)
+
+	assert.Equal(t, "debug", cfg.Log.Level, "remote overrides the profile file")
+	assert.Equal(t, 9100, cfg.Server.Port, "env overrides remote")
+	assert.Equal(t, "127.0.0.1", cfg.Server.Host)
+	assert.True(t, cfg.Remote.Enabled, "remote cannot disable itself")
+	assert.NotEmpty(t, cfg.RemoteVersion())
+
+	origin, ok := cfg.Origin("log.level")
+	require.True(t, ok)
+	assert.Equal(t, Origin{Source: SourceRemote, Detail: server.URL + "/v1/kv/go-service-template"}, origin)
+}
+
+// TestLoad_RemoteEnabledByEnv tests that env vars can configure the remote source.
+func TestLoad_RemoteEnabledByEnv(t *testing.T) {
+	doc := filepath.Join(t.TempDir(), "remote.json")
+	require.NoError(t, os.WriteFile(doc, []byte(`{"log": {"format": "text"}}`), 0o600))
+
+	t.Setenv("APP_REMOTE_ENABLED", "true")
+	t.Setenv("APP_REMOTE_URL", "file://"+doc)
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "text", cfg.Log.Format)
+}
+
+// TestWatcher_StartWithFileRemote tests that a file:// remote URL is not
+// resolved as a secret reference, so the watcher can poll it after Load.
+func TestWatcher_StartWithFileRemote(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	doc := filepath.Join(t.TempDir(), "doc.yaml")
+	require.NoError(t, os.WriteFile(doc, []byte("log:\n  level: debug\n"), 0o600))
+
+	t.Setenv("APP_REMOTE_ENABLED", "true")
+	t.Setenv("APP_REMOTE_URL", "file://"+doc)
+	t.Setenv("APP_REMOTE_POLL_INTERVAL", "1s")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+doc, cfg.Remote.URL)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Empty(t, cfg.SecretKeys())
+
+	w, err := NewWatcher(WatcherConfig{Initial: cfg})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, w.Start(ctx))
+}
+
+// TestLoad_RemoteFailure tests that an unreachable or invalid remote source fails the load.
+func TestLoad_RemoteFailure(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("APP_REMOTE_ENABLED", "true")
+		t.Setenv("APP_REMOTE_URL", "file:///nonexistent/remote.yaml")
+
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "loading remote config")
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		doc := filepath.Join(t.TempDir(), "remote.yaml")
+		require.NoError(t, os.WriteFile(doc, []byte("qa:\n  log:\n    level: info\n"), 0o600))
+
+		t.Setenv("APP_REMOTE_ENABLED", "true")
+		t.Setenv("APP_REMOTE_URL", "file://"+doc)
+		t.Setenv("APP_REMOTE_PATH", "prod")
+
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `path "prod" not found`)
+	})
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		t.Setenv("APP_REMOTE_ENABLED", "true")
+		t.Setenv("APP_REMOTE_URL", "etcd://localhost:2379/config")
+
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no remote source registered for scheme "etcd"`)
+	})
+}
+
+// TestHTTPSource_ETag tests conditional fetches against the stand-in.
+func TestHTTPSource_ETag(t *testing.T) {
+	kv := t.TempDir()
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: info\n")
+
+	var requests atomic.Int32
+	standIn := NewRemoteStandIn(kv)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		standIn.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	source, err := NewRemoteSource(RemoteConfig{URL: server.URL + "/v1/kv/app"})
+	require.NoError(t, err)
+
+	doc, version, err := source.Fetch(context.Background(), "")
+	require.NoError(t, err)
+	assert.Contains(t, string(doc), "level: info")
+	assert.NotEmpty(t, version)
+
+	_, same, err := source.Fetch(context.Background(), version)
+	require.ErrorIs(t, err, ErrNotModified)
+	assert.Equal(t, version, same)
+
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: debug\n")
+
+	doc, changed, err := source.Fetch(context.Background(), version)
+	require.NoError(t, err)
+	assert.NotEqual(t, version, changed)
+	assert.Contains(t, string(doc), "level: debug")
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+// TestRemoteStandIn tests key lookup, missing keys and traversal protection.
+func TestRemoteStandIn(t *testing.T) {
+	kv := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(kv, "team"), 0o750))
+	writeStandInKey(t, kv, "team/app.json", `{"log": {"level": "warn"}}`)
+
+	server := httptest.NewServer(NewRemoteStandIn(kv))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/kv/team/app")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	resp, err = http.Get(server.URL + "/v1/kv/missing")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// TestWatcher_PollsRemote tests that a new remote version triggers a reload.
+func TestWatcher_PollsRemote(t *testing.T) {
+	kv := t.TempDir()
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: info\n")
+
+	server := httptest.NewServer(NewRemoteStandIn(kv))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "remote:\n  enabled: true\n  poll_interval: 1s\n  url: "+server.URL+"/v1/kv/app\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "info", w.Current().Log.Level)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, w.Start(ctx))
+
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: warn\n")
+
+	assert.Eventually(t, func() bool {
+		return w.Current().Log.Level == "warn"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+// syncBuffer is a bytes.Buffer safe for the watcher goroutine to log to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// countingSource counts the documents a RemoteSource serves.
+type countingSource struct {
+	RemoteSource
+	served atomic.Int32
+}
+
+func (s *countingSource) Fetch(ctx context.Context, version string) ([]byte, string, error) {
+	doc, newVersion, err := s.RemoteSource.Fetch(ctx, version)
+	if err == nil {
+		s.served.Add(1)
+	}
+
+	return doc, newVersion, err
+}
+
+// TestWatcher_PollRemoteSkipsRejectedVersion tests that an invalid remote
+// version is rejected once and not re-fetched until it changes.
+func TestWatcher_PollRemoteSkipsRejectedVersion(t *testing.T) {
+	kv := t.TempDir()
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: info\n")
+
+	server := httptest.NewServer(NewRemoteStandIn(kv))
+	defer server.Close()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "remote:\n  enabled: true\n  url: "+server.URL+"/v1/kv/app\n")
+
+	var logs syncBuffer
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test", Logger: slog.New(slog.NewTextHandler(&logs, nil))})
+	require.NoError(t, err)
+
+	inner, err := newHTTPSource(RemoteConfig{URL: server.URL + "/v1/kv/app"})
+	require.NoError(t, err)
+
+	source := &countingSource{RemoteSource: inner}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: verbose\n")
+
+	go w.pollRemote(ctx, source, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), "config reload failed")
+	}, 5*time.Second, 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), source.served.Load(), "rejected version is not fetched again")
+	assert.Equal(t, 1, strings.Count(logs.String(), "config reload failed"))
+	assert.Equal(t, "info", w.Current().Log.Level)
+
+	writeStandInKey(t, kv, "app.yaml", "log:\n  level: warn\n")
+
+	assert.Eventually(t, func() bool {
+		return w.Current().Log.Level == "warn"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,331 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultTerminationGracePeriod is the Kubernetes default
+// terminationGracePeriodSeconds. A longer shutdown timeout risks the pod
+// being killed mid-drain.
+const DefaultTerminationGracePeriod = 30 * time.Second
+
+// Severity classifies a rule finding.
+type Severity int
+
+const (
+	// SeverityError fails validation.
+	SeverityError Severity = iota
+
+	// SeverityWarning is reported but does not fail validation.
+	SeverityWarning
+)
+
+// Finding is a single cross-field rule violation.
+type Finding struct {
+	// Severity determines whether the finding fails validation.
+	Severity Severity
+
+	// Field is the dotted config key the finding is about, e.g. "client.timeout".
+	Field string
+
+	// Problem completes the sentence "<field> ...", e.g. "is shorter than ...".
+	Problem string
+}
+
+// String formats the finding as "<field> <problem>".
+func (f Finding) String() string {
+	return f.Field + " " + f.Problem
+}
+
+// Rule checks an invariant that spans several fields and returns a finding
+// for each violation. Rules only run once per-field tag validation passes, so
+// they may assume individual values are in range.
+type Rule func(c *Config) []Finding
+
+var (
+	rulesMu sync.RWMutex
+	rules   = []Rule{
+		retryIntervalRule,
+		clientTimeoutRule,
+		shutdownGraceRule,
+		redactPatternsRule,
+		redactHashKeyRule,
+		telemetryExporterRule,
+		telemetryTransportRule,
+	}
+)
+
+// RegisterRule adds a cross-field rule run by Validate and Warnings.
+// Register rules that depend on other packages (e.g. WriteTimeoutRule) at
+// startup. It is safe for concurrent use.
+func RegisterRule(r Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	rules = append(rules, r)
+}
+
+// Warnings runs the cross-field rules and returns warning findings, formatted
+// like validation errors. Callers should log them after Validate succeeds.
+func (c *Config) Warnings() []string {
+	var warnings []string
+
+	for _, f := range c.findings() {
+		if f.Severity == SeverityWarning {
+			warnings = append(warnings, f.String())
+		}
+	}
+
+	return warnings
+}
+
+// ruleErrors returns the error findings of the cross-field rules as strings.
+func (c *Config) ruleErrors() []string {
+	var errs []string
+
+	for _, f := range c.findings() {
+		if f.Severity == SeverityError {
+			errs = append(errs, f.String())
+		}
+	}
+
+	return errs
+}
+
+// findings runs every registered rule.
+func (c *Config) findings() []Finding {
+	rulesMu.RLock()
+	registered := slices.Clone(rules)
+	rulesMu.RUnlock()
+
+	var findings []Finding
+	for _, rule := range registered {
+		findings = append(findings, rule(c)...)
+	}
+
+	return findings
+}
+
+// WriteTimeoutRule returns a rule warning when server.write_timeout is shorter
+// than the router's per-request timeout. The server may then close the
+// connection before the timeout middleware can write its 504 response.
+func WriteTimeoutRule(requestTimeout time.Duration) Rule {
+	return func(c *Config) []Finding {
+		if c.Server.WriteTimeout >= requestTimeout {
+			return nil
+		}
+
+		return []Finding{{
+			Severity: SeverityWarning,
+			Field:    "server.write_timeout",
+			Problem: fmt.Sprintf("is %s, shorter than the request timeout (%s); slow requests may get no 504 response",
+				c.Server.WriteTimeout, requestTimeout),
+		}}
+	}
+}
+
+// clientSettings pairs a config key prefix with the client settings under it.
+type clientSettings struct {
+	prefix  string
+	timeout time.Duration
+	retry   RetryConfig
+}
+
+// allClientSettings returns the global client settings and each service's.
+func (c *Config) allClientSettings() []clientSettings {
+	settings := []clientSettings{{prefix: "client", timeout: c.Client.Timeout, retry: c.Client.Retry}}
+
+	for _, key := range slices.Sorted(maps.Keys(c.Services)) {
+		svc := c.Services[key]
+		settings = append(settings, clientSettings{
+			prefix:  "services." + key,
+			timeout: svc.Timeout,
+			retry:   svc.Retry,
+		})
+	}
+
+	return settings
+}
+
+// retryIntervalRule requires retry.initial_interval to be at most retry.max_interval.
+func retryIntervalRule(c *Config) []Finding {
+	var findings []Finding
+
+	for _, s := range c.allClientSettings() {
+		if s.retry.InitialInterval <= s.retry.MaxInterval {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Field:    s.prefix + ".retry.initial_interval",
+			Problem: fmt.Sprintf("is %s but must not exceed %s.retry.max_interval (%s)",
+				s.retry.InitialInterval, s.prefix, s.retry.MaxInterval),
+		})
+	}
+
+	return findings
+}
+
+// clientTimeoutRule warns when a client timeout is shorter than the longest
+// backoff between retries: requests then spend more time waiting than trying.
+func clientTimeoutRule(c *Config) []Finding {
+	var findings []Finding
+
+	for _, s := range c.allClientSettings() {
+		backoff := longestBackoff(s.retry)
+		if backoff == 0 || s.timeout >= backoff {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Field:    s.prefix + ".timeout",
+			Problem: fmt.Sprintf("is %s, shorter than one backoff cycle (up to %s with jitter)",
+				s.timeout, backoff),
+		})
+	}
+
+	return findings
+}
+
+// longestBackoff returns the longest wait between attempts, including jitter.
+// It returns 0 when retries are disabled.
+func longestBackoff(retry RetryConfig) time.Duration {
+	if retry.MaxAttempts <= 1 {
+		return 0
+	}
+
+	// The last wait precedes the final attempt: initial * multiplier^(attempts-2)
+	backoff := float64(retry.InitialInterval) * math.Pow(retry.Multiplier, float64(retry.MaxAttempts-2))
+	backoff = min(backoff, float64(retry.MaxInterval))
+
+	return time.Duration(backoff * (1 + retry.JitterFactor))
+}
+
+// shutdownGraceRule warns when the shutdown timeout is longer than the default
+// pod termination grace period.
+func shutdownGraceRule(c *Config) []Finding {
+	if c.Server.ShutdownTimeout <= DefaultTerminationGracePeriod {
+		return nil
+	}
+
+	return []Finding{{
+		Severity: SeverityWarning,
+		Field:    "server.shutdown_timeout",
+		Problem: fmt.Sprintf("is %s, longer than the default pod termination grace period (%s); "+
+			"raise terminationGracePeriodSeconds or the pod may be killed mid-drain",
+			c.Server.ShutdownTimeout, DefaultTerminationGracePeriod),
+	}}
+}
+
+// redactPatternsRule requires every log.redact.patterns entry to be a valid regular expression.
+func redactPatternsRule(c *Config) []Finding {
+	var findings []Finding
+
+	for i, pattern := range c.Log.Redact.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			findings = append(findings, Finding{
+				Severity: SeverityError,
+				Field:    fmt.Sprintf("log.redact.patterns[%d]", i),
+				Problem:  fmt.Sprintf("is not a valid regular expression: %v", err),
+			})
+		}
+	}
+
+	return findings
+}
+
+// redactHashKeyRule requires log.redact.hash_key with the hash style. An
+// unkeyed hash of a card number can be reversed by enumerating card numbers.
+func redactHashKeyRule(c *Config) []Finding {
+	r := c.Log.Redact
+	if r.Style != "hash" || r.HashKey != "" {
+		return nil
+	}
+
+	return []Finding{{
+		Severity: SeverityError,
+		Field:    "log.redact.hash_key",
+		Problem:  "is required with log.redact.style hash",
+	}}
+}
+
+// telemetryExporterRule requires an endpoint for the OTLP exporters and warns
+// that the stdout and file exporters do not export logs.
+func telemetryExporterRule(c *Config) []Finding {
+	t := c.Telemetry
+	if !t.Enabled {
+		return nil
+	}
+
+	if !t.usesOTLP() {
+		if !t.Logs {
+			return nil
+		}
+
+		return []Finding{{
+			Severity: SeverityWarning,
+			Field:    "telemetry.logs",
+			Problem:  fmt.Sprintf("has no effect with the %s exporter; logs are only exported via OTLP", t.Exporter),
+		}}
+	}
+
+	if t.Endpoint != "" {
+		return nil
+	}
+
+	return []Finding{{
+		Severity: SeverityError,
+		Field:    "telemetry.endpoint",
+		Problem:  fmt.Sprintf("is required for the %s exporter", cmp.Or(t.Exporter, "otlp-grpc")),
+	}}
+}
+
+// usesOTLP reports whether the selected exporter sends to an OTLP collector.
+func (t *TelemetryConfig) usesOTLP() bool {
+	return t.Exporter == "" || t.Exporter == "otlp-grpc" || t.Exporter == "otlp-http"
+}
+
+// telemetryTransportRule requires an https telemetry.endpoint when TLS options
+// are set, and warns when auth credentials would be sent in plaintext.
+func telemetryTransportRule(c *Config) []Finding {
+	t := c.Telemetry
+	if !t.Enabled || !t.usesOTLP() || t.Endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || u.Scheme == "https" {
+		return nil // Malformed URLs fail tag validation
+	}
+
+	var findings []Finding
+
+	// min_version has a default, so only the other TLS options count as set
+	if tls := t.TLS; tls.CAFile != "" || tls.CertFile != "" || tls.KeyFile != "" || tls.ServerName != "" {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Field:    "telemetry.tls",
+			Problem:  fmt.Sprintf("is set but telemetry.endpoint (%s) is not an https URL", t.Endpoint),
+		})
+	}
+
+	if t.BearerToken != "" || len(t.Headers) > 0 {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Field:    "telemetry.endpoint",
+			Problem:  "is not an https URL, so telemetry.bearer_token and telemetry.headers are sent in plaintext",
+		})
+	}
+
+	return findings
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate_RetryIntervalRule tests that initial_interval above max_interval fails validation.
+func TestValidate_RetryIntervalRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Client.Retry.InitialInterval = 10 * time.Second
+	cfg.Client.Retry.MaxInterval = time.Second
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		"client.retry.initial_interval is 10s but must not exceed client.retry.max_interval (1s)")
+}
+
+// TestValidate_RetryIntervalRule_PerService tests that service overrides are checked too.
+func TestValidate_RetryIntervalRule_PerService(t *testing.T) {
+	cfg := validConfig()
+	svc := cfg.Services["quote"]
+	svc.Retry.InitialInterval = 10 * time.Second
+	cfg.Services["quote"] = svc
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "services.quote.retry.initial_interval is 10s")
+}
+
+// TestValidate_RulesSkippedWhenTagsFail tests that cross-field rules only run on field-valid config.
+func TestValidate_RulesSkippedWhenTagsFail(t *testing.T) {
+	cfg := validConfig()
+	cfg.Client.Retry.InitialInterval = 10 * time.Second
+	cfg.Client.Retry.MaxInterval = time.Second
+	cfg.App.Name = ""
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.name is required")
+	assert.NotContains(t, err.Error(), "initial_interval")
+}
+
+// TestWarnings_ClientTimeoutRule tests that a timeout shorter than the longest backoff warns.
+func TestWarnings_ClientTimeoutRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Client.Timeout = 200 * time.Millisecond
+	cfg.Client.Retry = RetryConfig{
+		MaxAttempts:     3,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		JitterFactor:    0.5,
+	}
+
+	require.NoError(t, cfg.Validate(), "warnings do not fail validation")
+	assert.Contains(t, cfg.Warnings(),
+		"client.timeout is 200ms, shorter than one backoff cycle (up to 1.5s with jitter)")
+}
+
+// TestLongestBackoff tests the longest wait between attempts.
+func TestLongestBackoff(t *testing.T) {
+	retry := RetryConfig{
+		MaxAttempts:     4,
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      2,
+	}
+	assert.Equal(t, 400*time.Millisecond, longestBackoff(retry))
+
+	retry.MaxAttempts = 10
+	assert.Equal(t, time.Second, longestBackoff(retry), "capped at max_interval")
+
+	retry.MaxAttempts = 1
+	assert.Zero(t, longestBackoff(retry), "no retries, no backoff")
+}
+
+// TestWarnings_ShutdownGraceRule tests that a shutdown timeout above the pod grace period warns.
+func TestWarnings_ShutdownGraceRule(t *testing.T) {
+	cfg := validConfig()
+	assert.Empty(t, cfg.Warnings())
+
+	cfg.Server.ShutdownTimeout = DefaultTerminationGracePeriod
+	assert.Empty(t, cfg.Warnings())
+
+	cfg.Server.ShutdownTimeout = 45 * time.Second
+	require.NoError(t, cfg.Validate())
+
+	warnings := cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "server.shutdown_timeout is 45s, longer than the default pod termination grace period (30s)")
+}
+
+// TestWriteTimeoutRule tests the router timeout rule in isolation.
+func TestWriteTimeoutRule(t *testing.T) {
+	rule := WriteTimeoutRule(30 * time.Second)
+
+	cfg := validConfig()
+	cfg.Server.WriteTimeout = 35 * time.Second
+	assert.Empty(t, rule(cfg))
+
+	cfg.Server.WriteTimeout = 30 * time.Second
+	assert.Empty(t, rule(cfg), "equal timeouts are accepted")
+
+	cfg.Server.WriteTimeout = 15 * time.Second
+	findings := rule(cfg)
+	require.Len(t, findings, 1)
+	assert.Equal(t, SeverityWarning, findings[0].Severity)
+	assert.Equal(t,
+		"server.write_timeout is 15s, shorter than the request timeout (30s); slow requests may get no 504 response",
+		findings[0].String())
+}
+
+// TestRegisterRule tests that registered rules run during Validate.
+func TestRegisterRule(t *testing.T) {
+	rulesMu.RLock()
+	saved := rules
+	rulesMu.RUnlock()
+
+	t.Cleanup(func() {
+		rulesMu.Lock()
+		rules = saved
+		rulesMu.Unlock()
+	})
+
+	RegisterRule(func(c *Config) []Finding {
+		if c.App.Name == "forbidden" {
+			return []Finding{{Severity: SeverityError, Field: "app.name", Problem: "is reserved"}}
+		}
+
+		return nil
+	})
+
+	cfg := validConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.App.Name = "forbidden"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config validation failed:\n  app.name is reserved")
+}
+
+// TestValidate_RedactPatternsRule tests that invalid redaction patterns fail validation.
+func TestValidate_RedactPatternsRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Log.Redact.Patterns = []string{`cust-\d+`, `([a-z`}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.redact.patterns[1] is not a valid regular expression")
+	assert.NotContains(t, err.Error(), "patterns[0]")
+
+	_, err = cfg.Log.Redact.Regexps()
+	require.Error(t, err)
+}
+
+// TestValidate_RedactHashKeyRule tests that the hash style requires a hash key.
+func TestValidate_RedactHashKeyRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Log.Redact.Style = "hash"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.redact.hash_key is required with log.redact.style hash")
+
+	cfg.Log.Redact.HashKey = "k"
+	require.NoError(t, cfg.Validate())
+}
+
+// TestTelemetryTransportRule tests that TLS options require an https
+// endpoint and that plaintext credentials warn.
+func TestTelemetryTransportRule(t *testing.T) {
+	cfg := validConfig()
+	cfg.Telemetry = TelemetryConfig{
+		Enabled:     true,
+		Endpoint:    "https://collector:4317",
+		ServiceName: "test-service",
+		TLS:         TelemetryTLSConfig{CAFile: "/etc/ssl/collector-ca.pem", MinVersion: "1.3"},
+		BearerToken: "token",
+	}
+	require.NoError(t, cfg.Validate())
+	assert.Empty(t, cfg.Warnings())
+
+	cfg.Telemetry.Endpoint = "http://collector:4317"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "telemetry.tls is set but telemetry.endpoint (http://collector:4317) is not an https URL")
+
+	cfg.Telemetry.TLS = TelemetryTLSConfig{MinVersion: "1.2"}
+	require.NoError(t, cfg.Validate())
+
+	warnings := cfg.Warnings()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "telemetry.endpoint is not an https URL, so telemetry.bearer_token and telemetry.headers are sent in plaintext")
+}
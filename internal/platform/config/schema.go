@@ -0,0 +1,361 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+)
+
+// SchemaPath is the generated JSON Schema for configuration files, relative
+// to the working directory. Regenerate with `go generate ./internal/platform/config`.
+const SchemaPath = configDir + "/config.schema.json"
+
+//go:generate go run ../../../cmd/service config schema --output ../../../configs/config.schema.json
+
+// durationPattern matches time.ParseDuration strings such as "30s" or "1m30s".
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// jsonSchema is the subset of JSON Schema (draft 2020-12) that the generator
+// emits and the file validator understands.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"` // bool or *jsonSchema
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// JSONSchema returns a JSON Schema describing configuration files, generated
+// from the koanf, validate and desc tags on Config.
+//
+// Keys are never required: base and profile files are partial and are merged
+// with defaults, so completeness is checked by Validate after loading.
+func JSONSchema() ([]byte, error) {
+	out, err := json.MarshalIndent(configSchema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshalling schema: %w", err)
+	}
+
+	return append(out, '\n'), nil
+}
+
+// configSchema builds the schema for Config.
+func configSchema() *jsonSchema {
+	s := schemaFor(reflect.TypeFor[Config](), "", defaults())
+	s.Schema = "https://json-schema.org/draft/2020-12/schema"
+	s.Title = "go-service-template configuration"
+	s.Description = "Base and profile configuration files in " + configDir + "/"
+	s.Properties[includeKey] = &jsonSchema{
+		Description: "Config files merged beneath this one, relative to its directory",
+		Type:        "array",
+		Items:       &jsonSchema{Type: "string"},
+	}
+
+	return s
+}
+
+// schemaFor builds the schema for a Go type. prefix is the dotted key of t,
+// used to look up defaults.
+func schemaFor(t reflect.Type, prefix string, defs map[string]any) *jsonSchema {
+	if t == durationType {
+		return &jsonSchema{Type: "string", Pattern: durationPattern}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &jsonSchema{
+			Type:                 "object",
+			Properties:           make(map[string]*jsonSchema),
+			AdditionalProperties: false,
+		}
+
+		for field := range fieldsOf(t) {
+			key := field.Tag.Get("koanf")
+			path := joinKey(prefix, key)
+
+			prop := schemaFor(field.Type, path, defs)
+			prop.Description = field.Tag.Get("desc")
+			prop.Default = defs[path]
+			applyValidateTag(prop, field.Tag.Get("validate"), field.Type)
+
+			s.Properties[key] = prop
+		}
+
+		return s
+	case reflect.Map:
+		s := &jsonSchema{Type: "object", AdditionalProperties: true}
+		if t.Elem().Kind() != reflect.Interface {
+			s.AdditionalProperties = schemaFor(t.Elem(), "", nil)
+		}
+
+		return s
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: schemaFor(t.Elem(), "", nil)}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// fieldsOf yields the exported fields of t that carry a koanf tag.
+func fieldsOf(t reflect.Type) func(yield func(reflect.StructField) bool) {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("koanf") == "" {
+				continue
+			}
+
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// applyValidateTag maps validate rules onto schema keywords. Numeric min/max
+// become minimum/maximum; duration bounds are noted in the description since
+// JSON Schema cannot compare duration strings. Rules after dive apply to the
+// items of a list or the values of a map.
+func applyValidateTag(s *jsonSchema, tag string, t reflect.Type) {
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "dive":
+			rest := strings.Join(rules[i+1:], ",")
+			if _, after, ok := strings.Cut(rest, "endkeys"); ok {
+				rest = strings.TrimPrefix(after, ",") // Key rules have no schema keyword
+			}
+
+			if s.Items != nil {
+				applyValidateTag(s.Items, rest, t.Elem())
+			} else if values, ok := s.AdditionalProperties.(*jsonSchema); ok {
+				applyValidateTag(values, rest, t.Elem())
+			}
+
+			return
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "url":
+			s.Format = "uri"
+		case "min", "max":
+			if t == durationType {
+				s.Description += fmt.Sprintf(" (%s %s)", name, param)
+				continue
+			}
+
+			bound, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+
+			if name == "min" {
+				s.Minimum = &bound
+			} else {
+				s.Maximum = &bound
+			}
+		}
+	}
+}
+
+// ValidateFileSchema checks a YAML configuration file against the generated
+// schema: unknown keys, wrong types, enum values and numeric ranges. It
+// reports every violation, not just the first.
+func ValidateFileSchema(path string) error {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from trusted operator config
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	doc, err := yaml.Parser().Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var problems []string
+
+	checkSchema(configSchema(), "", doc, &problems)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s does not match schema:\n  %s", path, strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// ValidateProfileFiles checks the base file, the profile's file, its drop-ins
+// and every included file against the generated schema. Files that do not
+// exist are skipped.
+func ValidateProfileFiles(profile string) error {
+	files, err := configFiles(profile)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, path := range files {
+		if err := ValidateFileSchema(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSchema validates value against s, appending readable problems keyed
+// by dotted config path.
+func checkSchema(s *jsonSchema, path string, value any, problems *[]string) {
+	field := path
+	if field == "" {
+		field = "document"
+	}
+
+	if value == nil {
+		return // An empty YAML key leaves the default in place
+	}
+
+	switch s.Type {
+	case "object":
+		m, ok := value.(map[string]any)
+		if !ok {
+			*problems = append(*problems, field+" must be a mapping")
+			return
+		}
+
+		checkObject(s, path, m, problems)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			*problems = append(*problems, field+" must be a list")
+			return
+		}
+
+		for i, item := range items {
+			checkSchema(s.Items, fmt.Sprintf("%s[%d]", field, i), item, problems)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			*problems = append(*problems, field+" must be a string")
+			return
+		}
+
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			*problems = append(*problems, fmt.Sprintf("%s must be one of: %s", field, strings.Join(s.Enum, " ")))
+		}
+
+		if s.Pattern == durationPattern {
+			if _, err := time.ParseDuration(str); err != nil {
+				*problems = append(*problems, field+" must be a duration such as 30s or 1m")
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*problems = append(*problems, field+" must be true or false")
+		}
+	case "integer", "number":
+		checkNumber(s, field, value, problems)
+	}
+}
+
+// checkObject validates the properties of a mapping.
+func checkObject(s *jsonSchema, path string, m map[string]any, problems *[]string) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		child := joinKey(path, key)
+
+		if prop, ok := s.Properties[key]; ok {
+			checkSchema(prop, child, m[key], problems)
+			continue
+		}
+
+		switch extra := s.AdditionalProperties.(type) {
+		case *jsonSchema:
+			checkSchema(extra, child, m[key], problems)
+		case bool:
+			if !extra {
+				*problems = append(*problems, child+" is not a known config key")
+			}
+		}
+	}
+}
+
+// checkNumber validates integer and number values, including range bounds.
+func checkNumber(s *jsonSchema, field string, value any, problems *[]string) {
+	var n float64
+
+	switch v := value.(type) {
+	case int:
+		n = float64(v)
+	case int64:
+		n = float64(v)
+	case uint64:
+		n = float64(v)
+	case float64:
+		if s.Type == "integer" && v != float64(int64(v)) {
+			*problems = append(*problems, field+" must be an integer")
+			return
+		}
+
+		n = v
+	default:
+		*problems = append(*problems, fmt.Sprintf("%s must be a %s", field, s.Type))
+		return
+	}
+
+	if s.Minimum != nil && n < *s.Minimum {
+		*problems = append(*problems, fmt.Sprintf("%s must be at least %v", field, *s.Minimum))
+	}
+
+	if s.Maximum != nil && n > *s.Maximum {
+		*problems = append(*problems, fmt.Sprintf("%s must be at most %v", field, *s.Maximum))
+	}
+}
+
+// joinKey joins a dotted key prefix and a child key; either may be empty.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	if key == "" {
+		return prefix
+	}
+
+	return prefix + "." + key
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoConfigDir is the repository's configs directory relative to this package.
+const repoConfigDir = "../../../configs"
+
+// TestJSONSchema_CheckedInIsCurrent tests that configs/config.schema.json matches the generator.
+func TestJSONSchema_CheckedInIsCurrent(t *testing.T) {
+	want, err := JSONSchema()
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(filepath.Join(repoConfigDir, "config.schema.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got), "run `go generate ./internal/platform/config`")
+}
+
+// TestJSONSchema_DescribesTags tests that descriptions, enums, ranges and defaults come from struct tags.
+func TestJSONSchema_DescribesTags(t *testing.T) {
+	s := configSchema()
+
+	format := s.Properties["log"].Properties["format"]
+	assert.Equal(t, "string", format.Type)
+	assert.Equal(t, []string{"json", "text", "pretty"}, format.Enum)
+	assert.Equal(t, "Console log format", format.Description)
+
+	port := s.Properties["server"].Properties["port"]
+	assert.Equal(t, "integer", port.Type)
+	require.NotNil(t, port.Minimum)
+	require.NotNil(t, port.Maximum)
+	assert.InDelta(t, 1, *port.Minimum, 0)
+	assert.InDelta(t, 65535, *port.Maximum, 0)
+	assert.Equal(t, DefaultServerPort, port.Default)
+
+	readTimeout := s.Properties["server"].Properties["read_timeout"]
+	assert.Equal(t, durationPattern, readTimeout.Pattern)
+	assert.Contains(t, readTimeout.Description, "(min 1s)")
+
+	detectors := s.Properties["log"].Properties["redact"].Properties["detectors"]
+	assert.Equal(t, "array", detectors.Type)
+	assert.Nil(t, detectors.Enum, "rules after dive apply to the items")
+	assert.Equal(t, []string{"email", "card", "iban"}, detectors.Items.Enum)
+
+	assert.Equal(t, true, s.Properties["features"].AdditionalProperties)
+	assert.Equal(t, false, s.Properties["server"].AdditionalProperties)
+
+	out, err := JSONSchema()
+	require.NoError(t, err)
+	assert.True(t, json.Valid(out))
+}
+
+// TestValidateFileSchema_RepoProfiles tests that every checked-in config file matches the schema.
+func TestValidateFileSchema_RepoProfiles(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(repoConfigDir, "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			assert.NoError(t, ValidateFileSchema(path))
+		})
+	}
+}
+
+// TestValidateFileSchema_ReportsEveryProblem tests that unknown keys, types, enums and ranges are all reported.
+func TestValidateFileSchema_ReportsEveryProblem(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.yaml")
+	content := `log:
+  format: xml
+  levle: debug
+server:
+  port: 70000
+  read_timeout: soon
+  host: 42
+features:
+  anything: goes
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	err := ValidateFileSchema(path)
+	require.Error(t, err)
+
+	msg := err.Error()
+	assert.Contains(t, msg, "log.format must be one of: json text pretty")
+	assert.Contains(t, msg, "log.levle is not a known config key")
+	assert.Contains(t, msg, "server.port must be at most 65535")
+	assert.Contains(t, msg, "server.read_timeout must be a duration")
+	assert.Contains(t, msg, "server.host must be a string")
+	assert.NotContains(t, msg, "features")
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// ErrUnresolvedSecret is returned when a secret reference cannot be resolved.
+var ErrUnresolvedSecret = errors.New("unresolved secret reference")
+
+// SecretResolver resolves configuration values that reference secrets held
+// elsewhere, such as "file:///run/secrets/quote-api-key" or "env:OTHER_VAR".
+//
+// Register custom backends (Vault, cloud secret managers) with
+// RegisterSecretResolver before calling Load.
+type SecretResolver interface {
+	// Scheme returns the reference scheme handled by this resolver,
+	// without the trailing colon (e.g. "file", "env", "vault").
+	Scheme() string
+
+	// Resolve returns the secret value for the full reference string.
+	Resolve(ref string) (string, error)
+}
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]SecretResolver{
+		"file": fileResolver{},
+		"env":  envResolver{},
+	}
+)
+
+// RegisterSecretResolver adds or replaces the resolver for its scheme.
+// It is safe for concurrent use.
+func RegisterSecretResolver(r SecretResolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	resolvers[r.Scheme()] = r
+}
+
+// resolverFor returns the resolver for a value, or nil if the value is not a
+// reference to a registered scheme.
+func resolverFor(value string) SecretResolver {
+	scheme, _, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil
+	}
+
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+
+	return resolvers[scheme]
+}
+
+// remotePrefix is the section configuring the remote layer. Its keys locate
+// the remote document and are never secret references: remote.url may be a
+// file:// stand-in for it.
+const remotePrefix = "remote."
+
+// resolveSecrets replaces secret references in k with their resolved values.
+// It returns the resolved values keyed by config key so callers can redact them.
+// Keys under remotePrefix are left as they are.
+func resolveSecrets(k *koanf.Koanf) (map[string]string, error) {
+	secrets := make(map[string]string)
+
+	for _, key := range k.Keys() {
+		if strings.HasPrefix(key, remotePrefix) {
+			continue
+		}
+
+		value, ok := k.Get(key).(string)
+		if !ok {
+			continue
+		}
+
+		resolver := resolverFor(value)
+		if resolver == nil {
+			continue
+		}
+
+		resolved, err := resolver.Resolve(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w for %s (%s): %w", ErrUnresolvedSecret, key, resolver.Scheme(), err)
+		}
+
+		if err := k.Set(key, resolved); err != nil {
+			return nil, fmt.Errorf("setting resolved secret for %s: %w", key, err)
+		}
+
+		secrets[key] = resolved
+	}
+
+	return secrets, nil
+}
+
+// secretTag marks a config field holding a secret. Its value is redacted by
+// RedactedValues however it was set, not only when it comes from a secret
+// reference. On a map field, every value of the map is secret.
+const secretTag = "secret"
+
+// secretFields lists the keys of fields tagged secret:"true". Map fields end
+// with "." so that their entries match by prefix.
+var secretFields = sync.OnceValue(func() []string {
+	return secretFieldKeys(reflect.TypeFor[Config](), "")
+})
+
+// secretFieldKeys returns the keys of secret fields in t, a struct whose
+// fields are loaded under prefix.
+func secretFieldKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+
+	for field := range fieldsOf(t) {
+		key := joinKey(prefix, field.Tag.Get("koanf"))
+
+		switch {
+		case field.Tag.Get(secretTag) == "true" && field.Type.Kind() == reflect.Map:
+			keys = append(keys, key+".")
+		case field.Tag.Get(secretTag) == "true":
+			keys = append(keys, key)
+		case field.Type.Kind() == reflect.Struct:
+			keys = append(keys, secretFieldKeys(field.Type, key)...)
+		}
+	}
+
+	return keys
+}
+
+// isSecretField reports whether key is a field tagged secret:"true" or an
+// entry of such a map field.
+func isSecretField(key string) bool {
+	for _, field := range secretFields() {
+		if key == field || (strings.HasSuffix(field, ".") && strings.HasPrefix(key+".", field)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SecretKeys returns the config keys whose values were resolved from secret
+// references, sorted for stable output.
+func (c *Config) SecretKeys() []string {
+	keys := make([]string, 0, len(c.secrets))
+	for key := range c.secrets {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// minContainSecretLength is the shortest secret value SecretValues returns.
+// Log handlers redact every string containing one of these values, so a
+// short value would mask unrelated text.
+const minContainSecretLength = 8
+
+// SecretValues returns secret values so that log handlers can redact them
+// wherever they appear: the values of fields tagged secret:"true", however
+// they were set, and values resolved from secret references on other keys
+// that look like secrets rather than words (see looksSecret). Values shorter
+// than minContainSecretLength are omitted, so an env: reference resolving to
+// "info" does not redact every log line containing "info". RedactedValues
+// still redacts every resolved reference.
+func (c *Config) SecretValues() []string {
+	seen := make(map[string]bool)
+
+	for key, value := range c.values {
+		s, ok := value.(string)
+		if !ok || len(s) < minContainSecretLength {
+			continue
+		}
+
+		if _, resolved := c.secrets[key]; isSecretField(key) || (resolved && looksSecret(s)) {
+			seen[s] = true
+		}
+	}
+
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// looksSecret reports whether s mixes at least two character classes
+// (lower case, upper case, digits, other), unlike a word such as "production".
+func looksSecret(s string) bool {
+	var lower, upper, digit, other int
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+
+	return lower+upper+digit+other >= 2
+}
+
+// fileResolver reads secrets from files, e.g. "file:///run/secrets/api-key".
+// Relative paths ("file:secrets/api-key") are resolved from the working directory.
+// A single trailing newline is trimmed, as written by most secret mounts.
+type fileResolver struct{}
+
+// Scheme implements SecretResolver.
+func (fileResolver) Scheme() string { return "file" }
+
+// Resolve implements SecretResolver.
+func (fileResolver) Resolve(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("parsing file reference: %w", err)
+	}
+
+	path := u.Path
+	if path == "" {
+		path = u.Opaque
+	}
+
+	if path == "" {
+		return "", errors.New("file reference has no path")
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from trusted operator config
+	if err != nil {
+		return "", fmt.Errorf("reading secret file: %w", err)
+	}
+
+	value := strings.TrimSuffix(string(data), "\n")
+
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+// envResolver reads secrets from another environment variable, e.g. "env:QUOTE_API_KEY".
+type envResolver struct{}
+
+// Scheme implements SecretResolver.
+func (envResolver) Scheme() string { return "env" }
+
+// Resolve implements SecretResolver.
+func (envResolver) Resolve(ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "env:")
+	if name == "" {
+		return "", errors.New("env reference has no variable name")
+	}
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad_ResolvesFileSecret tests that file:// references are replaced with file contents.
+func TestLoad_ResolvesFileSecret(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "audience")
+	require.NoError(t, os.WriteFile(secretPath, []byte("s3cr3t-audience\n"), 0o600))
+
+	t.Setenv("APP_AUTH_AUDIENCE", "file://"+secretPath)
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cr3t-audience", cfg.Auth.Audience)
+	assert.Equal(t, []string{"auth.audience"}, cfg.SecretKeys())
+	assert.Equal(t, []string{"s3cr3t-audience"}, cfg.SecretValues())
+}
+
+// TestLoad_ResolvesEnvSecret tests that env: references read another variable.
+func TestLoad_ResolvesEnvSecret(t *testing.T) {
+	t.Setenv("QUOTE_ISSUER", "https://issuer.example.com")
+	t.Setenv("APP_AUTH_ISSUER", "env:QUOTE_ISSUER")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://issuer.example.com", cfg.Auth.Issuer)
+	assert.Contains(t, cfg.SecretKeys(), "auth.issuer")
+}
+
+// TestSecretValues_SkipsWords tests that references resolving to common words
+// are redacted in config output but not returned for log redaction, while
+// secret fields are returned however they were set.
+func TestSecretValues_SkipsWords(t *testing.T) {
+	t.Setenv("LEVEL", "info")
+	t.Setenv("ENVIRONMENT", "production")
+	t.Setenv("APP_LOG_LEVEL", "env:LEVEL")
+	t.Setenv("APP_APP_ENVIRONMENT", "env:ENVIRONMENT")
+	t.Setenv("APP_TELEMETRY_BEARER_TOKEN", "plaintexttoken")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "info", cfg.Log.Level)
+	assert.Equal(t, "[REDACTED]", cfg.RedactedValues()["log.level"])
+	assert.Equal(t, "[REDACTED]", cfg.RedactedValues()["app.environment"])
+	assert.Equal(t, []string{"plaintexttoken"}, cfg.SecretValues())
+}
+
+// TestLoad_UnresolvedSecret tests that missing secrets fail the load and name the key.
+func TestLoad_UnresolvedSecret(t *testing.T) {
+	t.Setenv("APP_AUTH_AUDIENCE", "file:///nonexistent/audience")
+
+	_, err := Load("")
+	require.ErrorIs(t, err, ErrUnresolvedSecret)
+	assert.Contains(t, err.Error(), "auth.audience")
+}
+
+// TestLoad_NonSecretURLsUntouched tests that ordinary URLs are not treated as references.
+func TestLoad_NonSecretURLsUntouched(t *testing.T) {
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.quotable.io", cfg.Services["quote"].BaseURL)
+	assert.Empty(t, cfg.SecretKeys())
+}
+
+// stubResolver resolves "stub:" references to a fixed value.
+type stubResolver struct{}
+
+func (stubResolver) Scheme() string                 { return "stub" }
+func (stubResolver) Resolve(string) (string, error) { return "from-stub", nil }
+
+// TestRegisterSecretResolver tests that custom resolvers are used by Load.
+func TestRegisterSecretResolver(t *testing.T) {
+	RegisterSecretResolver(stubResolver{})
+	t.Cleanup(func() {
+		resolversMu.Lock()
+		delete(resolvers, "stub")
+		resolversMu.Unlock()
+	})
+
+	t.Setenv("APP_AUTH_ISSUER", "stub:anything")
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "from-stub", cfg.Auth.Issuer)
+}
+
+func TestFileResolver_RelativePath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key"), []byte("value"), 0o600))
+
+	value, err := fileResolver{}.Resolve("file:key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+)
+
+// clientPrefix is the key prefix of the global client settings that each
+// service inherits.
+const clientPrefix = "client."
+
+// inheritClientSettings copies every client.* key into each services.<name>
+// entry that does not set it, so a service only declares what it overrides.
+// A missing name defaults to the service key. Inherited keys keep the origin
+// of the client key they came from.
+func inheritClientSettings(k *koanf.Koanf, origins map[string]Origin) error {
+	var clientKeys []string
+
+	for _, key := range k.Keys() {
+		if strings.HasPrefix(key, clientPrefix) {
+			clientKeys = append(clientKeys, key)
+		}
+	}
+
+	for _, service := range k.MapKeys("services") {
+		prefix := "services." + service + "."
+
+		if !k.Exists(prefix + "name") {
+			if err := k.Set(prefix+"name", service); err != nil {
+				return fmt.Errorf("setting name for service %s: %w", service, err)
+			}
+
+			origins[prefix+"name"] = Origin{Source: SourceDefaults, Detail: "service key"}
+		}
+
+		for _, key := range clientKeys {
+			target := prefix + strings.TrimPrefix(key, clientPrefix)
+			if k.Exists(target) {
+				continue
+			}
+
+			if err := k.Set(target, k.Get(key)); err != nil {
+				return fmt.Errorf("inheriting %s for service %s: %w", key, service, err)
+			}
+
+			origins[target] = inheritedOrigin(origins[key], key)
+		}
+	}
+
+	return nil
+}
+
+// inheritedOrigin describes a value copied from another key.
+func inheritedOrigin(from Origin, key string) Origin {
+	detail := "inherited from " + key
+	if from.Detail != "" {
+		detail = from.Detail + ", " + detail
+	}
+
+	return Origin{Source: from.Source, Detail: detail}
+}
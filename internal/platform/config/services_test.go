@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoad_ServicesInheritClientSettings tests that services inherit unset client settings and keep their overrides.
+func TestLoad_ServicesInheritClientSettings(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", `client:
+  timeout: 10s
+services:
+  payments:
+    base_url: https://payments.example.com
+    timeout: 2s
+    retry:
+      max_attempts: 1
+    circuit_breaker:
+      max_failures: 2
+`)
+
+	cfg, err := Load("test")
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	quote := cfg.Services["quote"]
+	assert.Equal(t, "quote-service", quote.Name)
+	assert.Equal(t, 10*time.Second, quote.Timeout)
+	assert.Equal(t, cfg.Client.Retry, quote.Retry)
+
+	payments := cfg.Services["payments"]
+	assert.Equal(t, "payments", payments.Name, "name defaults to the service key")
+	assert.Equal(t, 2*time.Second, payments.Timeout)
+	assert.Equal(t, 1, payments.Retry.MaxAttempts)
+	assert.Equal(t, cfg.Client.Retry.InitialInterval, payments.Retry.InitialInterval)
+	assert.Equal(t, 2, payments.CircuitBreaker.MaxFailures)
+	assert.Equal(t, cfg.Client.CircuitBreaker.Timeout, payments.CircuitBreaker.Timeout)
+	assert.Equal(t, cfg.Client.Transport, payments.Transport)
+
+	origin, ok := cfg.Origin("services.payments.retry.max_interval")
+	require.True(t, ok)
+	assert.Equal(t, SourceDefaults, origin.Source)
+	assert.Equal(t, "inherited from client.retry.max_interval", origin.Detail)
+
+	origin, ok = cfg.Origin("services.payments.timeout")
+	require.True(t, ok)
+	assert.Equal(t, Origin{Source: SourceProfile, Detail: "configs/test.yaml"}, origin)
+}
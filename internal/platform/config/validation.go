@@ -13,12 +13,19 @@ var validate = validator.New(validator.WithRequiredStructEnabled())
 
 // Validate validates the configuration and returns an error if invalid.
 // Validation fails fast - the service should not start with invalid config.
+//
+// Per-field tag rules run first; once they pass, the registered cross-field
+// rules run and any error findings are reported in the same format.
 func (c *Config) Validate() error {
 	err := validate.Struct(c)
 	if err != nil {
 		return formatValidationErrors(err)
 	}
 
+	if errs := c.ruleErrors(); len(errs) > 0 {
+		return validationFailed(errs)
+	}
+
 	return nil
 }
 
@@ -36,6 +43,11 @@ func formatValidationErrors(err error) error {
 		errs = append(errs, formatFieldError(e))
 	}
 
+	return validationFailed(errs)
+}
+
+// validationFailed joins readable field errors into a single error.
+func validationFailed(errs []string) error {
 	return fmt.Errorf("config validation failed:\n  %s", strings.Join(errs, "\n  "))
 }
 
@@ -61,8 +73,12 @@ func formatFieldError(e validator.FieldError) string {
 	}
 }
 
-// formatFieldPath converts "Config.Server.Port" to "server.port".
+// formatFieldPath converts "Config.Server.Port" to "server.port" and
+// "Config.Services[quote].BaseURL" to "services.quote.baseurl".
 func formatFieldPath(namespace string) string {
+	// Map keys appear as [key]; treat them as path segments
+	namespace = strings.NewReplacer("[", ".", "]", "").Replace(namespace)
+
 	// Remove the root struct name (Config.)
 	parts := strings.Split(namespace, ".")
 	if len(parts) > 1 {
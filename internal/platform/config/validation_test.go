@@ -11,7 +11,7 @@ import (
 
 // validConfig returns a fully valid configuration for testing.
 func validConfig() *Config {
-	return &Config{
+	cfg := &Config{
 		App: AppConfig{
 			Name:        "test-service",
 			Version:     "1.0.0",
@@ -50,13 +50,20 @@ func validConfig() *Config {
 				IdleConnTimeout:     90 * time.Second,
 			},
 		},
-		Services: ServicesConfig{
-			Quote: ServiceEndpointConfig{
-				BaseURL: "https://api.quotable.io",
-				Name:    "quote-service",
-			},
+	}
+
+	cfg.Services = ServicesConfig{
+		"quote": {
+			BaseURL:        "https://api.quotable.io",
+			Name:           "quote-service",
+			Timeout:        cfg.Client.Timeout,
+			Retry:          cfg.Client.Retry,
+			CircuitBreaker: cfg.Client.CircuitBreaker,
+			Transport:      cfg.Client.Transport,
 		},
 	}
+
+	return cfg
 }
 
 func TestConfig_Validate_ValidConfig(t *testing.T) {
@@ -299,6 +306,39 @@ func TestConfig_Validate_TelemetryConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), "telemetry.endpoint")
 	})
 
+	t.Run("telemetry enabled - stdout exporter needs no endpoint", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Telemetry.Enabled = true
+		cfg.Telemetry.Exporter = "stdout"
+		cfg.Telemetry.Endpoint = ""
+		cfg.Telemetry.ServiceName = "test"
+
+		require.NoError(t, cfg.Validate())
+		assert.Empty(t, cfg.Warnings())
+
+		cfg.Telemetry.Logs = true
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, []string{"telemetry.logs has no effect with the stdout exporter; logs are only exported via OTLP"}, cfg.Warnings())
+	})
+
+	t.Run("telemetry sampling - route ratio out of range", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Telemetry.Sampling.Routes = map[string]float64{"/-/": 0, "/api/v1/quotes": 1.5}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "telemetry.sampling.routes")
+	})
+
+	t.Run("telemetry sampling - route must be a path", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Telemetry.Sampling.Routes = map[string]float64{"api": 0.5}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "telemetry.sampling.routes")
+	})
+
 	t.Run("telemetry enabled - service name required", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Telemetry.Enabled = true
@@ -535,6 +575,39 @@ func TestConfig_Validate_CircuitBreakerConfig(t *testing.T) {
 	})
 }
 
+func TestConfig_Validate_ServicesConfig(t *testing.T) {
+	t.Run("missing base url", func(t *testing.T) {
+		cfg := validConfig()
+		svc := cfg.Services["quote"]
+		svc.BaseURL = ""
+		cfg.Services["quote"] = svc
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "services.quote.baseurl is required")
+	})
+
+	t.Run("per-service retry is validated", func(t *testing.T) {
+		cfg := validConfig()
+		svc := cfg.Services["quote"]
+		svc.Retry.MaxAttempts = 0
+		cfg.Services["payments"] = svc
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "services.payments.retry.maxattempts")
+	})
+
+	t.Run("no services", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Services = nil
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "services is required")
+	})
+}
+
 func TestConfig_Validate_MultipleErrors(t *testing.T) {
 	cfg := &Config{
 		App: AppConfig{
@@ -567,6 +640,7 @@ func TestFormatFieldPath(t *testing.T) {
 		{"Config.Client.Retry.MaxAttempts", "client.retry.maxattempts"},
 		{"Config.Log.File.Path", "log.file.path"},
 		{"Config.Telemetry.SamplingRate", "telemetry.samplingrate"},
+		{"Config.Services[quote].BaseURL", "services.quote.baseurl"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/knadh/koanf/providers/file"
+)
+
+// Subscriber is notified after a reload produces a new, valid configuration.
+// prev is the configuration that was active before the reload.
+type Subscriber func(prev, next *Config)
+
+// WatcherConfig configures a configuration Watcher.
+type WatcherConfig struct {
+	// Profile is the config profile to reload (e.g., "local", "prod").
+	Profile string
+
+	// Initial is the already loaded and validated configuration.
+	// If nil, the watcher loads and validates the profile itself.
+	Initial *Config
+
+	// Logger is an optional logger. If nil, a default logger is used.
+	Logger *slog.Logger
+}
+
+// Watcher reloads configuration when config files change, the remote source
+// publishes a new version, or the process receives SIGHUP. Each reload re-runs
+// the layered Load and Validate; invalid results are rejected and the last good
+// configuration stays active.
+//
+// Only settings that consumers re-read at runtime (log level, client retry and
+// circuit breaker settings, feature toggles) take effect live. Server settings
+// such as port and timeouts still require a restart.
+//
+// The watched files are listed again after each valid reload, so an
+// include or drop-in file added later is watched from the next reload on
+// (e.g. SIGHUP, or an edit to a file already watched). Adding it alone does
+// not trigger a reload.
+type Watcher struct {
+	profile string
+	logger  *slog.Logger
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex // Serializes reloads and protects subscribers
+	subscribers []Subscriber
+
+	watchMu sync.Mutex            // Protects watched
+	watched map[string]*file.File // Watched config files by path; nil when not started
+}
+
+// NewWatcher creates a watcher for the given profile.
+// Call Start to begin watching for changes.
+func NewWatcher(cfg WatcherConfig) (*Watcher, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	initial := cfg.Initial
+	if initial == nil {
+		loaded, err := Load(cfg.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := loaded.Validate(); err != nil {
+			return nil, err
+		}
+
+		initial = loaded
+	}
+
+	w := &Watcher{
+		profile: cfg.Profile,
+		logger:  logger.With(slog.String("component", "config.Watcher")),
+	}
+	w.current.Store(initial)
+
+	return w, nil
+}
+
+// Current returns the active configuration snapshot.
+// The returned value must be treated as read-only.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be called after every successful reload that
+// changes the configuration. Subscribers run synchronously, in registration order.
+func (w *Watcher) Subscribe(fn Subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload re-runs the layered load and validation for the watcher's profile.
+// On success the new snapshot is published to subscribers; on failure the
+// current configuration is kept and the error is returned.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next, err := Load(w.profile)
+	if err != nil {
+		return fmt.Errorf("reloading config: %w", err)
+	}
+
+	if err := next.Validate(); err != nil {
+		return fmt.Errorf("rejecting reloaded config: %w", err)
+	}
+
+	// Pick up include and drop-in files added since the last reload
+	if err := w.watchFiles(); err != nil {
+		w.logger.Warn("watching new config files", slog.Any("error", err))
+	}
+
+	for _, u := range next.UnknownKeys() {
+		w.logger.Warn("ignoring unknown config key", slog.String("detail", u.String()))
+	}
+
+	for _, warning := range next.Warnings() {
+		w.logger.Warn("config warning", slog.String("detail", warning))
+	}
+
+	prev := w.current.Load()
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	}
+
+	if prev.Server != next.Server {
+		w.logger.Warn("server settings changed; restart required for them to take effect")
+	}
+
+	w.current.Store(next)
+
+	for _, fn := range w.subscribers {
+		fn(prev, next)
+	}
+
+	w.logger.Info("configuration reloaded", slog.String("profile", w.profile))
+
+	return nil
+}
+
+// Start watches the profile's config files, SIGHUP and, if enabled, the remote
+// source in the background until ctx is canceled. It returns an error if the
+// file watches cannot be set up.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.watchMu.Lock()
+	w.watched = make(map[string]*file.File)
+	w.watchMu.Unlock()
+
+	if err := w.watchFiles(); err != nil {
+		return errors.Join(err, w.unwatchFiles())
+	}
+
+	if remote := w.Current().Remote; remote.Enabled && remote.PollInterval > 0 {
+		source, err := NewRemoteSource(remote)
+		if err != nil {
+			return errors.Join(fmt.Errorf("creating remote source: %w", err), w.unwatchFiles())
+		}
+
+		go w.pollRemote(ctx, source, remote.PollInterval)
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				if err := w.unwatchFiles(); err != nil {
+					w.logger.Warn("stopping config file watch", slog.Any("error", err))
+				}
+
+				return
+			case <-hup:
+				w.reload("signal", slog.String("signal", "SIGHUP"))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// pollRemote checks the remote source for a new document version every
+// interval and reloads when it changes. The check is a conditional request
+// (If-None-Match), so an unchanged document costs no transfer. A version
+// whose reload fails is remembered and not retried until the remote
+// publishes another one.
+func (w *Watcher) pollRemote(ctx context.Context, source RemoteSource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var rejected string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, version, err := source.Fetch(ctx, cmp.Or(rejected, w.Current().RemoteVersion()))
+		if errors.Is(err, ErrNotModified) {
+			continue
+		}
+
+		if err != nil {
+			w.logger.Warn("polling remote config failed", slog.Any("error", err))
+			continue
+		}
+
+		// Sources that ignore the version may serve the rejected document again
+		if version != "" && version == rejected {
+			continue
+		}
+
+		rejected = ""
+		if !w.reload("remote", slog.String("version", version)) {
+			rejected = version
+		}
+	}
+}
+
+// reload runs Reload and logs the outcome with the trigger that caused it. It
+// reports whether the reload succeeded.
+func (w *Watcher) reload(trigger string, attrs ...any) bool {
+	if err := w.Reload(); err != nil {
+		w.logger.Error("config reload failed; keeping last good config",
+			append(attrs, slog.String("trigger", trigger), slog.Any("error", err))...,
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// watchFiles starts watching the profile's config files that are not watched
+// yet, such as an include or drop-in added since the last call. It does
+// nothing before Start or after the watcher stops.
+func (w *Watcher) watchFiles() error {
+	files, err := configFiles(w.profile)
+	if err != nil {
+		return fmt.Errorf("listing config files: %w", err)
+	}
+
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+
+	if w.watched == nil {
+		return nil
+	}
+
+	var errs []error
+
+	for _, path := range files {
+		if _, ok := w.watched[path]; ok {
+			continue
+		}
+
+		provider := file.Provider(path)
+
+		err := provider.Watch(func(_ any, err error) {
+			if err != nil {
+				w.logger.Warn("config file watch stopped",
+					slog.String("path", path),
+					slog.Any("error", err),
+				)
+
+				// Watched again if the file is back at the next reload
+				w.watchMu.Lock()
+				if w.watched[path] == provider {
+					delete(w.watched, path)
+				}
+				w.watchMu.Unlock()
+
+				return
+			}
+
+			w.reload("file", slog.String("path", path))
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("watching %s: %w", path, err))
+			continue
+		}
+
+		w.watched[path] = provider
+	}
+
+	return errors.Join(errs...)
+}
+
+// unwatchFiles stops all file watches and returns any errors encountered.
+func (w *Watcher) unwatchFiles() error {
+	w.watchMu.Lock()
+	defer w.watchMu.Unlock()
+
+	var errs []error
+
+	for _, provider := range w.watched {
+		if err := provider.Unwatch(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	w.watched = nil
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfile writes configs/{profile}.yaml under dir.
+func writeProfile(t *testing.T, dir, profile, content string) {
+	t.Helper()
+
+	configs := filepath.Join(dir, configDir)
+	require.NoError(t, os.MkdirAll(configs, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(configs, profile+".yaml"), []byte(content), 0o600))
+}
+
+// TestWatcher_Reload_PublishesValidChange tests that a valid change is published to subscribers.
+func TestWatcher_Reload_PublishesValidChange(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "log:\n  level: info\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "info", w.Current().Log.Level)
+
+	var gotPrev, gotNext *Config
+	w.Subscribe(func(prev, next *Config) {
+		gotPrev, gotNext = prev, next
+	})
+
+	writeProfile(t, dir, "test", "log:\n  level: debug\n")
+	require.NoError(t, w.Reload())
+
+	require.NotNil(t, gotNext)
+	assert.Equal(t, "info", gotPrev.Log.Level)
+	assert.Equal(t, "debug", gotNext.Log.Level)
+	assert.Equal(t, "debug", w.Current().Log.Level)
+}
+
+// TestWatcher_Reload_RejectsInvalidChange tests that an invalid change keeps the last good config.
+func TestWatcher_Reload_RejectsInvalidChange(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "log:\n  level: info\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+
+	called := false
+	w.Subscribe(func(_, _ *Config) { called = true })
+
+	writeProfile(t, dir, "test", "log:\n  level: verbose\n")
+	err = w.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.level")
+
+	assert.False(t, called, "subscribers should not see rejected config")
+	assert.Equal(t, "info", w.Current().Log.Level)
+}
+
+// TestWatcher_Reload_UnchangedSkipsSubscribers tests that reloading identical config is a no-op.
+func TestWatcher_Reload_UnchangedSkipsSubscribers(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "log:\n  level: info\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+
+	called := false
+	w.Subscribe(func(_, _ *Config) { called = true })
+
+	require.NoError(t, w.Reload())
+	assert.False(t, called)
+}
+
+// TestWatcher_Start_ReloadsOnFileChange tests that editing a watched file triggers a reload.
+func TestWatcher_Start_ReloadsOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "log:\n  level: info\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+
+	var reloads atomic.Int32
+	w.Subscribe(func(_, _ *Config) { reloads.Add(1) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, w.Start(ctx))
+
+	writeProfile(t, dir, "test", "log:\n  level: warn\n")
+
+	assert.Eventually(t, func() bool {
+		return reloads.Load() > 0 && w.Current().Log.Level == "warn"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestWatcher_Start_WatchesFilesAddedLater tests that a drop-in file added
+// after Start is watched once a reload picks it up.
+func TestWatcher_Start_WatchesFilesAddedLater(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeProfile(t, dir, "test", "log:\n  level: info\n")
+
+	w, err := NewWatcher(WatcherConfig{Profile: "test"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, w.Start(ctx))
+
+	writeConfigFile(t, dir, "test.d/10-level.yaml", "log:\n  level: warn\n")
+	require.NoError(t, w.Reload())
+	assert.Equal(t, "warn", w.Current().Log.Level)
+
+	writeConfigFile(t, dir, "test.d/10-level.yaml", "log:\n  level: error\n")
+
+	assert.Eventually(t, func() bool {
+		return w.Current().Log.Level == "error"
+	}, 2*time.Second, 10*time.Millisecond)
+}
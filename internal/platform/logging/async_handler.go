@@ -0,0 +1,261 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// OverflowPolicy decides what happens to a record logged while the async
+// queue is full.
+type OverflowPolicy string
+
+// Overflow policies for AsyncConfig.Policy.
+const (
+	OverflowBlock      OverflowPolicy = "block"       // Wait for room; nothing is lost
+	OverflowDropOldest OverflowPolicy = "drop_oldest" // Discard the oldest queued record
+	OverflowDropNewest OverflowPolicy = "drop_newest" // Discard the record being logged
+)
+
+// defaultAsyncQueueSize is used when AsyncConfig.Size is not positive.
+const defaultAsyncQueueSize = 1024
+
+// AsyncConfig configures an AsyncQueue.
+type AsyncConfig struct {
+	// Size is the number of records the queue holds before Policy applies.
+	Size int
+
+	// Policy is the overflow policy; empty means OverflowBlock.
+	Policy OverflowPolicy
+
+	// MeterProvider receives the log.queue.depth and log.records.dropped
+	// instruments. Defaults to the global provider, which forwards to the
+	// SDK provider once telemetry is initialized.
+	MeterProvider metric.MeterProvider
+}
+
+// AsyncQueue hands log records to a background goroutine so logging does not
+// wait on slow outputs (a file on a busy disk, a stalled OTLP exporter). The
+// queue is bounded; when it is full, the overflow policy blocks the caller or
+// drops a record. Shutdown drains the queue, after which records are written
+// synchronously. It is safe for concurrent use.
+type AsyncQueue struct {
+	policy OverflowPolicy
+	items  chan asyncItem
+	done   chan struct{} // Closed once the worker has drained the queue
+
+	mu     sync.RWMutex // Held for reading while enqueueing, for writing to close items
+	closed bool
+
+	dropped      atomic.Int64
+	droppedTotal metric.Int64Counter
+	dropAttrs    metric.AddOption
+	registration metric.Registration
+}
+
+// asyncItem is a record waiting to be written by the handler that logged it.
+type asyncItem struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// NewAsyncQueue creates a queue and starts its worker. Pass it as
+// Config.Async and call Shutdown before exit to flush queued records.
+func NewAsyncQueue(cfg AsyncConfig) (*AsyncQueue, error) {
+	size := cfg.Size
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+
+	policy := cfg.Policy
+	if policy == "" {
+		policy = OverflowBlock
+	}
+
+	provider := cfg.MeterProvider
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	q := &AsyncQueue{
+		policy:    policy,
+		items:     make(chan asyncItem, size),
+		done:      make(chan struct{}),
+		dropAttrs: metric.WithAttributes(attribute.String("policy", string(policy))),
+	}
+
+	meter := provider.Meter(otelScope)
+
+	droppedTotal, err := meter.Int64Counter(
+		"log.records.dropped",
+		metric.WithDescription("Log records dropped because the async log queue was full"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating dropped records counter: %w", err)
+	}
+
+	depth, err := meter.Int64ObservableGauge(
+		"log.queue.depth",
+		metric.WithDescription("Log records waiting in the async log queue"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating queue depth gauge: %w", err)
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(depth, int64(q.Len()))
+		return nil
+	}, depth)
+	if err != nil {
+		return nil, fmt.Errorf("registering queue depth callback: %w", err)
+	}
+
+	q.droppedTotal = droppedTotal
+	q.registration = registration
+
+	go q.run()
+
+	return q, nil
+}
+
+// Len returns the number of records waiting to be written.
+func (q *AsyncQueue) Len() int {
+	return len(q.items)
+}
+
+// Dropped returns the number of records dropped by the overflow policy.
+func (q *AsyncQueue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+// Shutdown stops queueing and waits until the records already queued are
+// written or ctx is done. Records logged afterwards are written synchronously.
+// Calling it again only waits for the drain.
+func (q *AsyncQueue) Shutdown(ctx context.Context) error {
+	var unregisterErr error
+
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.items)
+
+		// Stop observing depth; the worker keeps draining either way
+		unregisterErr = q.registration.Unregister()
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+	case <-ctx.Done():
+		return fmt.Errorf("flushing log queue (%d records left): %w", q.Len(), ctx.Err())
+	}
+
+	if unregisterErr != nil {
+		return fmt.Errorf("unregistering queue depth callback: %w", unregisterErr)
+	}
+
+	return nil
+}
+
+// run writes queued records until the queue is closed and empty. Output
+// errors are dropped, as slog.Logger drops them for synchronous handlers.
+func (q *AsyncQueue) run() {
+	defer close(q.done)
+
+	for item := range q.items {
+		_ = item.handler.Handle(item.ctx, item.record)
+	}
+}
+
+// enqueue queues item, applying the overflow policy when the queue is full.
+// It reports false once the queue is shut down, for the caller to write the
+// record itself.
+func (q *AsyncQueue) enqueue(item asyncItem) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return false
+	}
+
+	switch q.policy {
+	case OverflowDropNewest:
+		select {
+		case q.items <- item:
+		default:
+			q.drop(item.ctx)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.items <- item:
+				return true
+			default:
+			}
+
+			// Full: discard the oldest record, unless the worker just took it
+			select {
+			case <-q.items:
+				q.drop(item.ctx)
+			default:
+			}
+		}
+	default: // OverflowBlock
+		q.items <- item
+	}
+
+	return true
+}
+
+// drop counts a record dropped by the overflow policy.
+func (q *AsyncQueue) drop(ctx context.Context) {
+	q.dropped.Add(1)
+	q.droppedTotal.Add(ctx, 1, q.dropAttrs)
+}
+
+// asyncHandler hands records to an AsyncQueue, to be written by next on the
+// queue's worker. Handlers derived through WithAttrs and WithGroup share the
+// queue, so records keep their order across loggers.
+type asyncHandler struct {
+	next  slog.Handler
+	queue *AsyncQueue
+}
+
+// newAsyncHandler wraps next so records are written through queue.
+func newAsyncHandler(queue *AsyncQueue, next slog.Handler) *asyncHandler {
+	return &asyncHandler{next: next, queue: queue}
+}
+
+// Enabled reports whether next handles records at level.
+func (h *asyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle queues the record. The record is cloned, since its attributes may
+// be shared with the caller, and the context is detached from cancellation
+// so a finished request does not affect how the record is exported.
+func (h *asyncHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	item := asyncItem{ctx: context.WithoutCancel(ctx), handler: h.next, record: r.Clone()}
+	if h.queue.enqueue(item) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a new asyncHandler with the given attributes added.
+func (h *asyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &asyncHandler{next: h.next.WithAttrs(attrs), queue: h.queue}
+}
+
+// WithGroup returns a new asyncHandler with the given group name.
+func (h *asyncHandler) WithGroup(name string) slog.Handler {
+	return &asyncHandler{next: h.next.WithGroup(name), queue: h.queue}
+}
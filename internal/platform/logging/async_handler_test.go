@@ -0,0 +1,209 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// gatedHandler holds records until release is closed, then records them.
+// started receives once per record the worker picks up.
+type gatedHandler struct {
+	*recordingHandler
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGatedHandler() *gatedHandler {
+	return &gatedHandler{
+		recordingHandler: newRecordingHandler(),
+		started:          make(chan struct{}, 100),
+		release:          make(chan struct{}),
+	}
+}
+
+func (h *gatedHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	h.started <- struct{}{}
+	<-h.release
+
+	return h.recordingHandler.Handle(ctx, r)
+}
+
+// newTestQueue creates a queue of the given size whose worker is stuck on
+// the first record, so later records stay queued until gate.release closes.
+func newTestQueue(t *testing.T, size int, policy OverflowPolicy) (*slog.Logger, *AsyncQueue, *gatedHandler, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	queue, err := NewAsyncQueue(AsyncConfig{
+		Size:          size,
+		Policy:        policy,
+		MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+
+	gate := newGatedHandler()
+	logger := slog.New(newAsyncHandler(queue, gate))
+
+	logger.Info("first")
+	<-gate.started // The worker holds "first"; the queue is empty again
+
+	return logger, queue, gate, reader
+}
+
+// collectInt64 returns the value of the named sum or gauge metric.
+func collectInt64(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				require.Len(t, data.DataPoints, 1)
+				return data.DataPoints[0].Value
+			case metricdata.Gauge[int64]:
+				require.Len(t, data.DataPoints, 1)
+				return data.DataPoints[0].Value
+			}
+		}
+	}
+
+	t.Fatalf("metric %s not found", name)
+
+	return 0
+}
+
+func TestAsyncQueue_WritesInOrderAndFlushes(t *testing.T) {
+	logger, queue, gate, reader := newTestQueue(t, 10, OverflowBlock)
+
+	logger.Info("second")
+	logger.With("k", "v").Info("third")
+
+	assert.Equal(t, 2, queue.Len())
+	assert.Equal(t, int64(2), collectInt64(t, reader, "log.queue.depth"))
+
+	close(gate.release)
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"first", "second", "third"}, gate.messages())
+	assert.Zero(t, queue.Dropped())
+}
+
+func TestAsyncQueue_DropNewest(t *testing.T) {
+	logger, queue, gate, reader := newTestQueue(t, 2, OverflowDropNewest)
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		logger.Info(msg)
+	}
+
+	assert.Equal(t, int64(2), queue.Dropped())
+	assert.Equal(t, int64(2), collectInt64(t, reader, "log.records.dropped"))
+
+	close(gate.release)
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"first", "a", "b"}, gate.messages())
+}
+
+func TestAsyncQueue_DropOldest(t *testing.T) {
+	logger, queue, gate, reader := newTestQueue(t, 2, OverflowDropOldest)
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		logger.Info(msg)
+	}
+
+	assert.Equal(t, int64(2), queue.Dropped())
+	assert.Equal(t, int64(2), collectInt64(t, reader, "log.records.dropped"))
+
+	close(gate.release)
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"first", "c", "d"}, gate.messages())
+}
+
+func TestAsyncQueue_BlockWaitsForRoom(t *testing.T) {
+	logger, queue, gate, _ := newTestQueue(t, 1, OverflowBlock)
+
+	logger.Info("a") // Fills the queue
+
+	logged := make(chan struct{})
+	go func() {
+		logger.Info("b")
+		close(logged)
+	}()
+
+	select {
+	case <-logged:
+		t.Fatal("logging did not block on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(gate.release)
+	<-logged
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"first", "a", "b"}, gate.messages())
+	assert.Zero(t, queue.Dropped())
+}
+
+func TestAsyncQueue_ShutdownTimeout(t *testing.T) {
+	logger, queue, gate, _ := newTestQueue(t, 10, OverflowBlock)
+	defer close(gate.release)
+
+	logger.Info("stuck")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := queue.Shutdown(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "1 records left")
+}
+
+func TestAsyncQueue_SynchronousAfterShutdown(t *testing.T) {
+	queue, err := NewAsyncQueue(AsyncConfig{Size: 4})
+	require.NoError(t, err)
+
+	rec := newRecordingHandler()
+	logger := slog.New(newAsyncHandler(queue, rec))
+
+	require.NoError(t, queue.Shutdown(context.Background()))
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	logger.Info("late")
+
+	assert.Equal(t, []string{"late"}, rec.messages())
+}
+
+func TestNewWithWriter_Async(t *testing.T) {
+	queue, err := NewAsyncQueue(AsyncConfig{Size: 16})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	logger := NewWithWriter(&Config{Level: "info", Format: "json", Service: "svc", Async: queue}, &buf)
+	logger.Info("queued", "password", "hunter2")
+
+	require.NoError(t, queue.Shutdown(context.Background()))
+
+	out := buf.String()
+	assert.Equal(t, 1, strings.Count(out, "\n"))
+	assert.Contains(t, out, `"msg":"queued"`)
+	assert.Contains(t, out, `"service_name":"svc"`)
+	assert.NotContains(t, out, "hunter2")
+}
@@ -7,45 +7,88 @@ import (
 
 type ctxKey struct{}
 
+// ctxEntry is the logging state stored in a context: the request-scoped
+// logger and, optionally, a minimum level for records logged with it.
+type ctxEntry struct {
+	logger   *slog.Logger
+	level    slog.Level
+	hasLevel bool
+}
+
 var defaultLogger = slog.Default()
 
-// FromContext extracts the logger from context.
-// Returns the default logger if no logger is found or ctx is nil.
-func FromContext(ctx context.Context) *slog.Logger {
+// entryFromContext returns the logging state stored in ctx, if any.
+func entryFromContext(ctx context.Context) (ctxEntry, bool) {
 	if ctx == nil {
-		return defaultLogger
+		return ctxEntry{}, false
 	}
 
-	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
-		return logger
+	entry, ok := ctx.Value(ctxKey{}).(ctxEntry)
+
+	return entry, ok
+}
+
+// FromContext extracts the logger from context.
+// Returns the default logger if no logger is found or ctx is nil.
+func FromContext(ctx context.Context) *slog.Logger {
+	if entry, ok := entryFromContext(ctx); ok && entry.logger != nil {
+		return entry.logger
 	}
 
 	return defaultLogger
 }
 
-// WithContext stores a logger in the context.
+// WithContext stores a logger in the context, keeping any level set by WithLevel.
 func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
-	return context.WithValue(ctx, ctxKey{}, logger)
+	entry, _ := entryFromContext(ctx)
+	entry.logger = logger
+
+	return context.WithValue(ctx, ctxKey{}, entry)
+}
+
+// WithLevel sets the minimum level for records logged with ctx (the
+// *Context methods and Log), replacing the global and per-component levels
+// of loggers from New for that context only. Use it to turn on verbose
+// logging for a single request.
+func WithLevel(ctx context.Context, level slog.Level) context.Context {
+	entry, _ := entryFromContext(ctx)
+	entry.level = level
+	entry.hasLevel = true
+
+	return context.WithValue(ctx, ctxKey{}, entry)
+}
+
+// LevelFromContext returns the minimum level set by WithLevel, if any.
+func LevelFromContext(ctx context.Context) (slog.Level, bool) {
+	entry, ok := entryFromContext(ctx)
+	if !ok || !entry.hasLevel {
+		return 0, false
+	}
+
+	return entry.level, true
 }
 
 // WithRequestID adds a request ID to the logger in context.
 // Returns a new context with the enriched logger.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
-	logger := FromContext(ctx).With(slog.String("request_id", requestID))
+	logger := FromContext(ctx).With(slog.String(RequestIDKey, requestID))
 	return WithContext(ctx, logger)
 }
 
 // WithTraceID adds a trace ID to the logger in context.
 // Returns a new context with the enriched logger.
+//
+// Deprecated: loggers from New add trace_id from the active span of each
+// record's context; log with the *Context methods instead.
 func WithTraceID(ctx context.Context, traceID string) context.Context {
-	logger := FromContext(ctx).With(slog.String("trace_id", traceID))
+	logger := FromContext(ctx).With(slog.String(TraceIDKey, traceID))
 	return WithContext(ctx, logger)
 }
 
 // WithCorrelationID adds a correlation ID to the logger in context.
 // Returns a new context with the enriched logger.
 func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
-	logger := FromContext(ctx).With(slog.String("correlation_id", correlationID))
+	logger := FromContext(ctx).With(slog.String(CorrelationIDKey, correlationID))
 	return WithContext(ctx, logger)
 }
 
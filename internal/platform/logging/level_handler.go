@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// levelHandler gates an slog.Handler behind a dynamic minimum level.
+// The wrapped handler accepts everything down to LevelTrace; levelHandler
+// applies the global level (a slog.LevelVar changed on config reload) or,
+// when the logger is bound to a component or downstream with an override in
+// Levels, that override instead. A level set on the record's context with
+// WithLevel takes precedence over both.
+type levelHandler struct {
+	level   slog.Leveler
+	levels  *Levels
+	handler slog.Handler
+
+	// keys are the downstream and component names bound via WithAttrs,
+	// most specific first.
+	keys []string
+
+	// grouped is set once WithGroup is called; later attributes are nested
+	// and no longer identify the component.
+	grouped bool
+}
+
+// newLevelHandler wraps handler so records below level, or below the
+// logger's component override in levels (may be nil), are dropped.
+func newLevelHandler(handler slog.Handler, level slog.Leveler, levels *Levels) *levelHandler {
+	return &levelHandler{level: level, levels: levels, handler: handler}
+}
+
+// Enabled reports whether level is at or above the current minimum level.
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minLevel := h.level.Level()
+	if ctxLevel, ok := LevelFromContext(ctx); ok {
+		minLevel = ctxLevel
+	} else if override, ok := h.levels.lookup(h.keys); ok {
+		minLevel = override
+	}
+
+	return level >= minLevel && h.handler.Enabled(ctx, level)
+}
+
+// Handle passes the record to the wrapped handler.
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	return h.handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new levelHandler with the given attributes added.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.handler = h.handler.WithAttrs(attrs)
+
+	if !h.grouped {
+		next.keys = withLevelKeys(h.keys, attrs)
+	}
+
+	return &next
+}
+
+// WithGroup returns a new levelHandler with the given group name.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	next := *h
+	next.handler = h.handler.WithGroup(name)
+	next.grouped = true
+
+	return &next
+}
+
+// withLevelKeys returns keys extended with any component or downstream
+// names in attrs. Downstream names go first as they are more specific.
+func withLevelKeys(keys []string, attrs []slog.Attr) []string {
+	var component, downstream []string
+
+	for _, a := range attrs {
+		switch a.Key {
+		case ComponentKey:
+			component = append(component, a.Value.String())
+		case DownstreamKey:
+			downstream = append(downstream, a.Value.String())
+		}
+	}
+
+	if len(component) == 0 && len(downstream) == 0 {
+		return keys
+	}
+
+	// Newer bindings are more specific than ones inherited from a parent logger
+	return slices.Concat(downstream, component, keys)
+}
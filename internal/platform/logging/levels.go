@@ -0,0 +1,186 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Attribute keys that select a per-component level override.
+const (
+	ComponentKey  = "component"
+	DownstreamKey = "downstream"
+)
+
+// LevelOverride describes an active per-component level override.
+type LevelOverride struct {
+	// Key is the component or downstream name (e.g. "clients.Client", "quote").
+	Key string
+
+	// Level is the minimum level for loggers bound to Key.
+	Level slog.Level
+
+	// ExpiresAt is when the override reverts to the global level.
+	// Zero means the override does not expire.
+	ExpiresAt time.Time
+}
+
+// Levels is a registry of per-component minimum log levels, keyed by the
+// value of a logger's "component" or "downstream" attribute. It lets one
+// noisy component (e.g. component=clients.Client) log at trace while the rest
+// of the service stays at the global level. Overrides can expire, so debug
+// logging reverts automatically.
+//
+// Overrides apply to loggers whose attribute was bound with Logger.With, not
+// to attributes passed with a single log call. It is safe for concurrent use.
+type Levels struct {
+	mu      sync.Mutex // Serializes writers; readers use the snapshot
+	entries map[string]*levelEntry
+
+	// snapshot maps keys to their levels and is replaced on every change,
+	// so the logging hot path never takes the lock.
+	snapshot atomic.Pointer[map[string]*slog.LevelVar]
+}
+
+// levelEntry is one override and its pending expiry.
+type levelEntry struct {
+	level     *slog.LevelVar
+	expiresAt time.Time
+	timer     *time.Timer
+}
+
+// NewLevels creates an empty level registry.
+func NewLevels() *Levels {
+	l := &Levels{entries: make(map[string]*levelEntry)}
+	l.publish()
+
+	return l
+}
+
+// Set overrides the minimum level for key. If ttl is positive the override
+// is removed after ttl; setting the key again restarts the countdown. It
+// returns when the override expires, or the zero time if it does not.
+func (l *Levels) Set(key string, level slog.Level, ttl time.Duration) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if old, ok := l.entries[key]; ok && old.timer != nil {
+		old.timer.Stop()
+	}
+
+	entry := &levelEntry{level: new(slog.LevelVar)}
+	entry.level.Set(level)
+
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+		entry.timer = time.AfterFunc(ttl, func() { l.expire(key, entry) })
+	}
+
+	l.entries[key] = entry
+	l.publish()
+
+	return entry.expiresAt
+}
+
+// Reset removes the override for key. It reports whether one was set.
+func (l *Levels) Reset(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok {
+		return false
+	}
+
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+
+	delete(l.entries, key)
+	l.publish()
+
+	return true
+}
+
+// Overrides returns the active overrides, sorted by key.
+func (l *Levels) Overrides() []LevelOverride {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	overrides := make([]LevelOverride, 0, len(l.entries))
+	for _, key := range slices.Sorted(maps.Keys(l.entries)) {
+		entry := l.entries[key]
+		overrides = append(overrides, LevelOverride{
+			Key:       key,
+			Level:     entry.level.Level(),
+			ExpiresAt: entry.expiresAt,
+		})
+	}
+
+	return overrides
+}
+
+// lookup returns the override for the first key that has one.
+func (l *Levels) lookup(keys []string) (slog.Level, bool) {
+	if l == nil || len(keys) == 0 {
+		return 0, false
+	}
+
+	snapshot := *l.snapshot.Load()
+	for _, key := range keys {
+		if level, ok := snapshot[key]; ok {
+			return level.Level(), true
+		}
+	}
+
+	return 0, false
+}
+
+// expire removes entry if it is still the override for key.
+func (l *Levels) expire(key string, entry *levelEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.entries[key] != entry {
+		return // Replaced or reset since the timer was armed
+	}
+
+	delete(l.entries, key)
+	l.publish()
+}
+
+// publish replaces the lock-free snapshot. Callers must hold l.mu.
+func (l *Levels) publish() {
+	snapshot := make(map[string]*slog.LevelVar, len(l.entries))
+	for key, entry := range l.entries {
+		snapshot[key] = entry.level
+	}
+
+	l.snapshot.Store(&snapshot)
+}
+
+// ParseLevel converts a level name (trace, debug, info, warn, error) to an
+// slog.Level. Unlike the lenient parsing used for configuration, unknown
+// names are an error.
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "trace", "debug", "info", "warn", "warning", "error":
+		return parseLevel(level), nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q (want trace, debug, info, warn or error)", level)
+	}
+}
+
+// LevelName returns the lowercase name of level, using "trace" for LevelTrace.
+func LevelName(level slog.Level) string {
+	if level == LevelTrace {
+		return "trace"
+	}
+
+	return strings.ToLower(level.String())
+}
@@ -0,0 +1,170 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLevelsLogger(t *testing.T, levels *Levels) (*slog.Logger, *bytes.Buffer) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := NewWithWriter(&Config{
+		Level:  "info",
+		Format: "json",
+		Levels: levels,
+	}, &buf)
+
+	return logger, &buf
+}
+
+func TestLevels_OverrideRaisesOnlyMatchingComponent(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+
+	client := logger.With(slog.String(ComponentKey, "clients.Client"))
+	service := logger.With(slog.String(ComponentKey, "app.Service"))
+
+	levels.Set("clients.Client", LevelTrace, 0)
+
+	client.Log(t.Context(), LevelTrace, "client trace")
+	service.Debug("service debug")
+
+	assert.Contains(t, buf.String(), "client trace")
+	assert.NotContains(t, buf.String(), "service debug")
+}
+
+func TestLevels_OverrideCanLowerVerbosity(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+
+	levels.Set("noisy", slog.LevelError, 0)
+	logger.With(slog.String(ComponentKey, "noisy")).Info("noisy info")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestLevels_DownstreamTakesPrecedenceOverComponent(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+
+	quote := logger.With(slog.String(ComponentKey, "clients.Client"), slog.String(DownstreamKey, "quote"))
+	payments := logger.With(slog.String(ComponentKey, "clients.Client"), slog.String(DownstreamKey, "payments"))
+
+	levels.Set("clients.Client", slog.LevelWarn, 0)
+	levels.Set("quote", slog.LevelDebug, 0)
+
+	quote.Debug("quote debug")
+	payments.Info("payments info")
+
+	assert.Contains(t, buf.String(), "quote debug")
+	assert.NotContains(t, buf.String(), "payments info")
+}
+
+func TestLevels_GroupedAttrsDoNotSelectOverride(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+
+	levels.Set("nested", slog.LevelDebug, 0)
+	logger.WithGroup("request").With(slog.String(ComponentKey, "nested")).Debug("grouped debug")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestLevels_TTLRevertsToGlobalLevel(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+	client := logger.With(slog.String(ComponentKey, "clients.Client"))
+
+	expiresAt := levels.Set("clients.Client", slog.LevelDebug, 20*time.Millisecond)
+	assert.WithinDuration(t, time.Now().Add(20*time.Millisecond), expiresAt, time.Second)
+
+	client.Debug("before expiry")
+	assert.Contains(t, buf.String(), "before expiry")
+
+	require.Eventually(t, func() bool {
+		return len(levels.Overrides()) == 0
+	}, time.Second, 5*time.Millisecond)
+
+	client.Debug("after expiry")
+	assert.NotContains(t, buf.String(), "after expiry")
+}
+
+func TestLevels_SetRestartsTTL(t *testing.T) {
+	levels := NewLevels()
+
+	levels.Set("c", slog.LevelDebug, 20*time.Millisecond)
+	levels.Set("c", slog.LevelWarn, 0)
+
+	time.Sleep(50 * time.Millisecond)
+
+	overrides := levels.Overrides()
+	require.Len(t, overrides, 1)
+	assert.Equal(t, slog.LevelWarn, overrides[0].Level)
+	assert.True(t, overrides[0].ExpiresAt.IsZero())
+}
+
+func TestLevels_ResetAndOverrides(t *testing.T) {
+	levels := NewLevels()
+	levels.Set("b", slog.LevelDebug, time.Minute)
+	levels.Set("a", slog.LevelWarn, 0)
+
+	overrides := levels.Overrides()
+	require.Len(t, overrides, 2)
+	assert.Equal(t, "a", overrides[0].Key)
+	assert.Equal(t, "b", overrides[1].Key)
+
+	assert.True(t, levels.Reset("b"))
+	assert.False(t, levels.Reset("b"))
+	assert.Len(t, levels.Overrides(), 1)
+}
+
+func TestParseLevel_Strict(t *testing.T) {
+	level, err := ParseLevel("TRACE")
+	require.NoError(t, err)
+	assert.Equal(t, LevelTrace, level)
+
+	_, err = ParseLevel("verbose")
+	require.Error(t, err)
+}
+
+func TestLevelName(t *testing.T) {
+	assert.Equal(t, "trace", LevelName(LevelTrace))
+	assert.Equal(t, "debug", LevelName(slog.LevelDebug))
+	assert.Equal(t, "error", LevelName(slog.LevelError))
+}
+
+func TestWithLevel_ContextLevelTakesPrecedence(t *testing.T) {
+	levels := NewLevels()
+	logger, buf := newLevelsLogger(t, levels)
+	client := logger.With(slog.String(ComponentKey, "clients.Client"))
+
+	levels.Set("clients.Client", slog.LevelError, 0)
+	ctx := WithLevel(t.Context(), LevelTrace)
+
+	client.Log(ctx, LevelTrace, "request trace")
+	client.Warn("no context warn")
+	logger.DebugContext(t.Context(), "other request debug")
+
+	assert.Contains(t, buf.String(), "request trace")
+	assert.NotContains(t, buf.String(), "no context warn")
+	assert.NotContains(t, buf.String(), "other request debug")
+}
+
+func TestWithLevel_KeptByWithContext(t *testing.T) {
+	ctx := WithLevel(t.Context(), LevelTrace)
+	ctx = WithContext(ctx, slog.Default())
+	ctx = WithRequestID(ctx, "r1")
+
+	level, ok := LevelFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, LevelTrace, level)
+
+	_, ok = LevelFromContext(WithContext(t.Context(), slog.Default()))
+	assert.False(t, ok)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/log"
+	otellog "go.opentelemetry.io/otel/log"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -27,6 +28,41 @@ type Config struct {
 	Service string     // service name for default attrs
 	Version string     // service version for default attrs
 	File    FileConfig // rolling file configuration
+
+	// LevelVar, if set, holds the minimum level so it can be changed at
+	// runtime (e.g. on config reload). It is initialized from Level.
+	LevelVar *slog.LevelVar
+
+	// Levels, if set, holds per-component overrides of the minimum level,
+	// keyed by the logger's component or downstream attribute.
+	Levels *Levels
+
+	// Sampler, if set, drops repetitive info and debug records. Call its
+	// Close before exit to log the summary of the last window.
+	Sampler *Sampler
+
+	// LoggerProvider, if set, receives every record as an additional
+	// output, typically for OTLP export. Pass the global provider
+	// (go.opentelemetry.io/otel/log/global) to pick up the SDK provider
+	// once telemetry is initialized.
+	LoggerProvider otellog.LoggerProvider
+
+	// Buffer, if set, keeps the most recent records in memory as an
+	// additional output (see the /-/logs endpoint).
+	Buffer *RingBuffer
+
+	// Async, if set, writes records to the outputs on its background
+	// goroutine instead of the caller's. Call its Shutdown before exit to
+	// flush queued records.
+	Async *AsyncQueue
+
+	// Secrets are resolved secret values (see config.Config.SecretValues)
+	// that are redacted wherever they appear in a logged string.
+	Secrets []string
+
+	// Redact is the configurable redaction policy (extra field names,
+	// patterns and PII detectors) applied after the built-in secret rules.
+	Redact RedactConfig
 }
 
 // FileConfig holds rolling log file configuration.
@@ -43,42 +79,79 @@ type FileConfig struct {
 // For "pretty" format with file logging enabled, logs go to both:
 // - Terminal: colorful pretty-printed output
 // - File: structured JSON logs for aggregation
+// With a LoggerProvider set, records are also exported via OpenTelemetry, and
+// with a Buffer set, the most recent records are kept in memory. With an
+// Async queue set, outputs are written in the background.
 func New(cfg *Config) *slog.Logger {
 	return NewWithWriter(cfg, os.Stdout)
 }
 
 // NewWithWriter creates a new configured slog.Logger with a custom writer.
 // Includes secret redaction by default. See docs/SECRET_REDACTION.md for details.
+// Records logged with a context carrying an OpenTelemetry span get trace_id,
+// span_id and trace_flags attributes.
 func NewWithWriter(cfg *Config, w io.Writer) *slog.Logger {
-	level := parseLevel(cfg.Level)
+	level := cfg.LevelVar
+	if level == nil {
+		level = new(slog.LevelVar)
+	}
+	SetLevel(level, cfg.Level)
 
-	// Create the terminal handler based on format
+	replaceAttr := NewRedactor(cfg.Secrets, cfg.Redact).replaceAttr
+
+	// Output handlers accept every level; the minimum level (global or
+	// per-component) is applied once, by the levelHandler wrapping them all.
 	var terminalHandler slog.Handler
 	switch strings.ToLower(cfg.Format) {
 	case "pretty":
-		terminalHandler = newPrettyHandler(w, level)
+		terminalHandler = newPrettyHandler(w, LevelTrace)
 	case "text":
-		terminalHandler = newTextHandler(w, level)
+		terminalHandler = newTextHandler(w, LevelTrace, replaceAttr)
 	default: // "json"
-		terminalHandler = newJSONHandler(w, level)
+		terminalHandler = newJSONHandler(w, LevelTrace, replaceAttr)
 	}
 
-	// If file logging is enabled, create a multi-handler
-	var handler slog.Handler
+	// If file logging, OpenTelemetry export or the buffer is enabled, create a multi-handler
+	outputs := []slog.Handler{terminalHandler}
 	if cfg.File.Enabled && cfg.File.Path != "" {
-		fileHandler := newFileHandler(cfg.File, level)
-		handler = NewMultiHandler(terminalHandler, fileHandler)
+		outputs = append(outputs, newFileHandler(cfg.File, LevelTrace, replaceAttr))
+	}
+
+	if cfg.LoggerProvider != nil {
+		outputs = append(outputs, newOTelHandler(cfg.LoggerProvider, replaceAttr))
+	}
+
+	if cfg.Buffer != nil {
+		outputs = append(outputs, newRingHandler(cfg.Buffer, replaceAttr))
+	}
+
+	var handler slog.Handler
+	if len(outputs) > 1 {
+		handler = NewMultiHandler(outputs...)
 	} else {
 		handler = terminalHandler
 	}
 
+	// Hand records to the async queue; the handlers wrapping it still run on
+	// the caller's goroutine, so trace attributes come from the live context
+	if cfg.Async != nil {
+		handler = newAsyncHandler(cfg.Async, handler)
+	}
+
+	// Add trace_id, span_id and trace_flags from each record's context
+	handler = NewTraceHandler(handler)
+
 	// Add default attributes
-	logger := slog.New(handler).With(
+	handler = handler.WithAttrs([]slog.Attr{
 		slog.String("service_name", cfg.Service),
 		slog.String("service_version", cfg.Version),
-	)
+	})
 
-	return logger
+	if cfg.Sampler != nil {
+		handler = NewSamplingHandler(handler, cfg.Sampler)
+	}
+
+	return slog.New(newLevelHandler(handler, level, cfg.Levels))
 }
 
 // newPrettyHandler creates a charmbracelet/log handler for colorful terminal output.
@@ -95,28 +168,31 @@ func newPrettyHandler(w io.Writer, level slog.Level) slog.Handler {
 	return logger
 }
 
+// replaceAttrFunc is the signature of slog.HandlerOptions.ReplaceAttr.
+type replaceAttrFunc = func(groups []string, a slog.Attr) slog.Attr
+
 // newTextHandler creates a standard slog text handler with redaction.
-func newTextHandler(w io.Writer, level slog.Level) slog.Handler {
+func newTextHandler(w io.Writer, level slog.Leveler, replaceAttr replaceAttrFunc) slog.Handler {
 	opts := &slog.HandlerOptions{
 		Level:       level,
-		ReplaceAttr: NewReplaceAttr(),
+		ReplaceAttr: replaceAttr,
 	}
 	return slog.NewTextHandler(w, opts)
 }
 
 // newJSONHandler creates a standard slog JSON handler with redaction.
 // AddSource enables source file and line info for debugging.
-func newJSONHandler(w io.Writer, level slog.Level) slog.Handler {
+func newJSONHandler(w io.Writer, level slog.Leveler, replaceAttr replaceAttrFunc) slog.Handler {
 	opts := &slog.HandlerOptions{
 		AddSource:   true,
 		Level:       level,
-		ReplaceAttr: NewReplaceAttr(),
+		ReplaceAttr: replaceAttr,
 	}
 	return slog.NewJSONHandler(w, opts)
 }
 
 // newFileHandler creates a JSON handler writing to rolling log files.
-func newFileHandler(cfg FileConfig, level slog.Level) slog.Handler {
+func newFileHandler(cfg FileConfig, level slog.Leveler, replaceAttr replaceAttrFunc) slog.Handler {
 	// Ensure log directory exists
 	dir := filepath.Dir(cfg.Path)
 	if dir != "" && dir != "." {
@@ -135,7 +211,7 @@ func newFileHandler(cfg FileConfig, level slog.Level) slog.Handler {
 
 	opts := &slog.HandlerOptions{
 		Level:       level,
-		ReplaceAttr: NewReplaceAttr(),
+		ReplaceAttr: replaceAttr,
 	}
 
 	// File logs are always JSON for structured log aggregation
@@ -159,6 +235,12 @@ func slogToCharmLevel(level slog.Level) log.Level {
 	}
 }
 
+// SetLevel updates lv to the given string log level.
+// Unknown levels fall back to info, matching New.
+func SetLevel(lv *slog.LevelVar, level string) {
+	lv.Set(parseLevel(level))
+}
+
 // parseLevel converts a string log level to slog.Level.
 func parseLevel(level string) slog.Level {
 	switch strings.ToLower(level) {
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// otelScope is the instrumentation scope of records bridged to OpenTelemetry.
+const otelScope = "github.com/jsamuelsen/go-service-template/logging"
+
+// otelHandler bridges slog records to an OpenTelemetry Logger. The provider
+// behind the Logger adds the service resource attributes, and the span
+// context of the context passed to Handle is attached to each record.
+// Attributes and the message go through replaceAttr first, so exported
+// records are redacted exactly like the terminal and file output.
+//
+// Group names become dotted prefixes of attribute keys (e.g. "request.id").
+type otelHandler struct {
+	logger      otellog.Logger
+	replaceAttr replaceAttrFunc
+
+	attrs  []otellog.KeyValue // Attributes bound via WithAttrs, already redacted
+	groups []string           // Groups opened via WithGroup
+}
+
+// newOTelHandler creates a handler emitting to a Logger from provider.
+func newOTelHandler(provider otellog.LoggerProvider, replaceAttr replaceAttrFunc) *otelHandler {
+	return &otelHandler{
+		logger:      provider.Logger(otelScope),
+		replaceAttr: replaceAttr,
+	}
+}
+
+// Enabled reports whether the provider wants records at level. It is false
+// until an SDK provider is installed behind the global delegate.
+func (h *otelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: otelSeverity(level)})
+}
+
+// Handle converts the record and emits it with the span context from ctx.
+func (h *otelHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	var record otellog.Record
+	record.SetTimestamp(r.Time)
+	record.SetSeverity(otelSeverity(r.Level))
+	record.SetSeverityText(strings.ToUpper(LevelName(r.Level)))
+
+	msg := redactAttr(h.replaceAttr, nil, slog.String(slog.MessageKey, r.Message))
+	record.SetBody(otellog.StringValue(msg.Value.String()))
+
+	kvs := slices.Clip(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		kvs = h.appendAttr(kvs, h.groups, a)
+		return true
+	})
+	record.AddAttributes(kvs...)
+
+	h.logger.Emit(ctx, record)
+
+	return nil
+}
+
+// WithAttrs returns a new otelHandler with the given attributes added.
+func (h *otelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+
+	for _, a := range attrs {
+		h2.attrs = h.appendAttr(h2.attrs, h.groups, a)
+	}
+
+	return &h2
+}
+
+// WithGroup returns a new otelHandler with the given group name.
+func (h *otelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
+}
+
+// appendAttr redacts a and appends it to kvs, flattening groups into dotted keys.
+func (h *otelHandler) appendAttr(kvs []otellog.KeyValue, groups []string, a slog.Attr) []otellog.KeyValue {
+	flattenAttr(h.replaceAttr, groups, a, func(key string, v slog.Value) {
+		kvs = append(kvs, otellog.KeyValue{Key: key, Value: otelValue(v)})
+	})
+
+	return kvs
+}
+
+// otelSeverity maps slog levels onto OpenTelemetry severity numbers:
+// trace, debug, info, warn and error map to TRACE, DEBUG, INFO, WARN and ERROR.
+func otelSeverity(level slog.Level) otellog.Severity {
+	return otellog.Severity(level + 9) //nolint:gosec // slog levels are small
+}
+
+// otelValue converts a resolved slog value to an OpenTelemetry log value.
+// Durations are nanoseconds and times RFC 3339 strings, matching the JSON output.
+func otelValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		if u := v.Uint64(); u <= math.MaxInt64 {
+			return otellog.Int64Value(int64(u))
+		}
+
+		return otellog.StringValue(strconv.FormatUint(v.Uint64(), 10))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.Int64Value(v.Duration().Nanoseconds())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		kvs := make([]otellog.KeyValue, 0, len(v.Group()))
+		for _, a := range v.Group() {
+			kvs = append(kvs, otellog.KeyValue{Key: a.Key, Value: otelValue(a.Value.Resolve())})
+		}
+
+		return otellog.MapValue(kvs...)
+	default: // slog.KindAny, including errors
+		return otellog.StringValue(fmt.Sprint(v.Any()))
+	}
+}
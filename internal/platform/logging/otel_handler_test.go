@@ -0,0 +1,158 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/m-mizutani/masq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingProcessor collects emitted OpenTelemetry records.
+type recordingProcessor struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (p *recordingProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+
+func (p *recordingProcessor) OnEmit(_ context.Context, r *sdklog.Record) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.records = append(p.records, r.Clone())
+
+	return nil
+}
+
+func (p *recordingProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *recordingProcessor) ForceFlush(context.Context) error { return nil }
+
+func (p *recordingProcessor) attrs(i int) map[string]otellog.Value {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	attrs := map[string]otellog.Value{}
+	p.records[i].WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	return attrs
+}
+
+func newOTelLogger(t *testing.T, secrets ...string) (*slog.Logger, *recordingProcessor, *bytes.Buffer) {
+	t.Helper()
+
+	proc := &recordingProcessor{}
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(resource.NewSchemaless(semconv.ServiceName("test-service"))),
+		sdklog.WithProcessor(proc),
+	)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	var buf bytes.Buffer
+	logger := NewWithWriter(&Config{
+		Level:          "info",
+		Format:         "json",
+		Service:        "test-service",
+		Secrets:        secrets,
+		LoggerProvider: provider,
+	}, &buf)
+
+	return logger, proc, &buf
+}
+
+func TestOTelHandler_ExportsWithResourceAndSpanContext(t *testing.T) {
+	logger, proc, buf := newOTelLogger(t)
+
+	traceID := trace.TraceID{1, 2, 3}
+	spanID := trace.SpanID{4, 5, 6}
+	ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.InfoContext(ctx, "order placed", slog.String("order_id", "o-1"))
+	logger.Debug("filtered by level")
+
+	assert.Contains(t, buf.String(), "order placed", "terminal output still receives records")
+	require.Len(t, proc.records, 1)
+
+	r := proc.records[0]
+	assert.Equal(t, "order placed", r.Body().AsString())
+	assert.Equal(t, otellog.SeverityInfo, r.Severity())
+	assert.Equal(t, "INFO", r.SeverityText())
+	assert.Equal(t, traceID, r.TraceID())
+	assert.Equal(t, spanID, r.SpanID())
+	assert.Equal(t, trace.FlagsSampled, r.TraceFlags())
+
+	serviceName, ok := r.Resource().Set().Value(semconv.ServiceNameKey)
+	require.True(t, ok)
+	assert.Equal(t, "test-service", serviceName.AsString())
+
+	attrs := proc.attrs(0)
+	assert.Equal(t, "o-1", attrs["order_id"].AsString())
+	assert.Equal(t, "test-service", attrs["service_name"].AsString())
+}
+
+func TestOTelHandler_RedactsBeforeExport(t *testing.T) {
+	logger, proc, _ := newOTelLogger(t, "s3cr3t-value")
+
+	logger.Info("connecting with s3cr3t-value",
+		slog.String("password", "hunter2"),
+		slog.String("dsn", "postgres://app:s3cr3t-value@db"),
+	)
+
+	require.Len(t, proc.records, 1)
+	assert.NotContains(t, proc.records[0].Body().AsString(), "s3cr3t-value")
+
+	attrs := proc.attrs(0)
+	assert.NotEqual(t, "hunter2", attrs["password"].AsString())
+	assert.NotContains(t, attrs["dsn"].AsString(), "s3cr3t-value")
+}
+
+func TestOTelHandler_GroupsBecomeDottedKeys(t *testing.T) {
+	logger, proc, _ := newOTelLogger(t)
+
+	logger.WithGroup("request").With(slog.String("id", "r-1")).Info("handled",
+		slog.Group("http", slog.Int("status", 200)),
+		slog.Duration("elapsed", 1500),
+	)
+
+	attrs := proc.attrs(0)
+	assert.Equal(t, "r-1", attrs["request.id"].AsString())
+	assert.Equal(t, int64(200), attrs["request.http.status"].AsInt64())
+	assert.Equal(t, int64(1500), attrs["request.elapsed"].AsInt64())
+}
+
+func TestOTelHandler_UsesReplaceAttrFromConfig(t *testing.T) {
+	proc := &recordingProcessor{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(proc))
+
+	replaceAttr := NewReplaceAttr(masq.WithFieldName("card"))
+	logger := slog.New(newOTelHandler(provider, replaceAttr))
+
+	logger.Info("charged", slog.String("card", "4111111111111111"))
+
+	require.Len(t, proc.records, 1)
+	assert.NotEqual(t, "4111111111111111", proc.attrs(0)["card"].AsString())
+}
+
+func TestOTelSeverity(t *testing.T) {
+	assert.Equal(t, otellog.SeverityTrace, otelSeverity(LevelTrace))
+	assert.Equal(t, otellog.SeverityDebug, otelSeverity(slog.LevelDebug))
+	assert.Equal(t, otellog.SeverityWarn, otelSeverity(slog.LevelWarn))
+	assert.Equal(t, otellog.SeverityError, otelSeverity(slog.LevelError))
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Built-in PII detectors, selected by name in RedactConfig.Detectors.
+const (
+	DetectorEmail = "email" // Email addresses
+	DetectorCard  = "card"  // Payment card numbers passing the Luhn check
+	DetectorIBAN  = "iban"  // IBANs passing the ISO 13616 mod-97 check
+)
+
+// detector finds one kind of PII inside string values.
+type detector struct {
+	// pattern finds candidates, which may include separators.
+	pattern *regexp.Regexp
+
+	// valid, if set, rejects candidates that only look like a match.
+	valid func(normalized string) bool
+
+	// normalize canonicalizes a candidate, so partial and hash styles see
+	// the same value however it was formatted.
+	normalize func(s string) string
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// 13 to 19 digits, optionally grouped with spaces or dashes.
+	cardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+
+	// Country code, check digits and an 11 to 30 character BBAN, optionally in groups of four.
+	ibanPattern = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`)
+)
+
+// detectors holds the built-in detectors by name.
+var detectors = map[string]detector{
+	DetectorEmail: {pattern: emailPattern, normalize: strings.ToLower},
+	DetectorCard:  {pattern: cardPattern, valid: luhnValid, normalize: stripSeparators},
+	DetectorIBAN:  {pattern: ibanPattern, valid: ibanValid, normalize: stripSeparators},
+}
+
+// find reports whether s contains a valid match.
+func (d detector) find(s string) bool {
+	for _, m := range d.pattern.FindAllString(s, -1) {
+		if d.valid == nil || d.valid(d.normalize(m)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replace replaces each valid match in s with mask applied to its normalized form.
+func (d detector) replace(s string, mask func(string) string) string {
+	return d.pattern.ReplaceAllStringFunc(s, func(m string) string {
+		n := d.normalize(m)
+		if d.valid != nil && !d.valid(n) {
+			return m
+		}
+
+		return mask(n)
+	})
+}
+
+// stripSeparators removes the spaces and dashes used to group digits.
+func stripSeparators(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// ibanValid reports whether s passes the ISO 13616 mod-97 check: with the
+// first four characters moved to the end and letters converted to 10-35,
+// the number leaves a remainder of 1 when divided by 97.
+func ibanValid(s string) bool {
+	rem := 0
+
+	for _, r := range s[4:] + s[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			rem = (rem*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			rem = (rem*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+
+	return rem == 1
+}
@@ -1,8 +1,13 @@
 package logging
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"log/slog"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/m-mizutani/masq"
 )
@@ -64,6 +69,126 @@ func DefaultRedactOptions() []masq.Option {
 	}
 }
 
+// SecretRedactOptions returns masq options that redact any string value
+// containing one of the given secrets, regardless of the attribute name.
+// Use it for values resolved from secret references at config load time.
+// Empty secrets are ignored since they would match every value.
+func SecretRedactOptions(secrets []string) []masq.Option {
+	opts := make([]masq.Option, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			opts = append(opts, masq.WithContain(secret))
+		}
+	}
+
+	return opts
+}
+
+// RedactStyle selects how RedactOptions replaces a matched value.
+type RedactStyle string
+
+const (
+	// RedactFull replaces the value with [REDACTED].
+	RedactFull RedactStyle = "full"
+
+	// RedactPartial keeps the last four characters, e.g. ****1234. Values
+	// shorter than eight characters are fully masked.
+	RedactPartial RedactStyle = "partial"
+
+	// RedactHash replaces the value with a truncated HMAC-SHA-256 hash,
+	// e.g. hash:9f86d081884c7d65, so equal values can still be correlated
+	// across records. It requires HashKey; without one, values are fully
+	// masked, as an unkeyed hash of a card number can be reversed.
+	RedactHash RedactStyle = "hash"
+)
+
+const (
+	redactedValue  = "[REDACTED]"
+	partialMask    = "****"
+	partialKeep    = 4
+	partialMinLen  = 8
+	hashHexLength  = 16
+	hashMaskPrefix = "hash:"
+)
+
+// RedactConfig is a redaction policy layered on the built-in secret rules.
+// The built-in field names and token patterns are always fully masked;
+// Style applies to matches of this policy.
+type RedactConfig struct {
+	Fields    []string         // Attribute names whose values are redacted
+	Patterns  []*regexp.Regexp // Matching parts of string values are redacted
+	Detectors []string         // Built-in PII detectors (DetectorEmail, DetectorCard, DetectorIBAN)
+	Style     RedactStyle      // Defaults to RedactFull
+	HashKey   string           // HMAC key for RedactHash; full masking if empty
+}
+
+// RedactOptions returns masq options for the policy in cfg. Fields redact
+// the whole value; patterns and detectors replace only the matching parts
+// of a string, so "card 4111 1111 1111 1111 declined" keeps its context.
+// Unknown detector names are ignored.
+func RedactOptions(cfg RedactConfig) []masq.Option {
+	mask := cfg.maskFunc()
+	whole := masq.RedactString(mask)
+
+	opts := make([]masq.Option, 0, len(cfg.Fields)+len(cfg.Patterns)+len(cfg.Detectors))
+	for _, field := range cfg.Fields {
+		opts = append(opts, masq.WithFieldName(field, whole))
+	}
+
+	found := make([]detector, 0, len(cfg.Patterns)+len(cfg.Detectors))
+	for _, pattern := range cfg.Patterns {
+		found = append(found, detector{pattern: pattern, normalize: func(s string) string { return s }})
+	}
+
+	for _, name := range cfg.Detectors {
+		if d, ok := detectors[name]; ok {
+			found = append(found, d)
+		}
+	}
+
+	for _, d := range found {
+		opts = append(opts, masq.WithCensor(
+			func(_ string, value any, _ string) bool {
+				s, ok := value.(string)
+				return ok && d.find(s)
+			},
+			masq.RedactString(func(s string) string { return d.replace(s, mask) }),
+		))
+	}
+
+	return opts
+}
+
+// maskFunc returns the function replacing a matched value in cfg.Style.
+func (cfg *RedactConfig) maskFunc() func(string) string {
+	switch cfg.Style {
+	case RedactPartial:
+		return func(s string) string {
+			r := []rune(s)
+			if len(r) < partialMinLen {
+				return partialMask
+			}
+
+			return partialMask + string(r[len(r)-partialKeep:])
+		}
+	case RedactHash:
+		if cfg.HashKey == "" {
+			return func(string) string { return redactedValue }
+		}
+
+		key := []byte(cfg.HashKey)
+
+		return func(s string) string {
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte(s))
+
+			return hashMaskPrefix + hex.EncodeToString(mac.Sum(nil))[:hashHexLength]
+		}
+	default:
+		return func(string) string { return redactedValue }
+	}
+}
+
 // NewReplaceAttr creates a ReplaceAttr function for slog.HandlerOptions
 // that redacts sensitive data. Uses DefaultRedactOptions which can be
 // extended for project-specific needs.
@@ -78,3 +203,43 @@ func NewReplaceAttr(opts ...masq.Option) func(groups []string, a slog.Attr) slog
 	allOpts := append(DefaultRedactOptions(), opts...)
 	return masq.New(allOpts...)
 }
+
+// flattenAttr resolves a and calls fn with the dotted key (groups joined
+// with ".") and redacted value of each leaf attribute. Empty groups and
+// attributes redacted to an empty key are skipped, as slog's built-in
+// handlers do. Handlers that keep records outside of a slog output use it
+// so their attributes are redacted like the terminal and file output.
+func flattenAttr(replaceAttr replaceAttrFunc, groups []string, a slog.Attr, fn func(key string, v slog.Value)) {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(slices.Clip(groups), a.Key)
+		}
+
+		for _, ga := range a.Value.Group() {
+			flattenAttr(replaceAttr, groups, ga, fn)
+		}
+
+		return
+	}
+
+	a = redactAttr(replaceAttr, groups, a)
+	if a.Key == "" {
+		return
+	}
+
+	fn(strings.Join(append(slices.Clip(groups), a.Key), "."), a.Value)
+}
+
+// redactAttr applies replaceAttr, if any, to a.
+func redactAttr(replaceAttr replaceAttrFunc, groups []string, a slog.Attr) slog.Attr {
+	if replaceAttr == nil {
+		return a
+	}
+
+	a = replaceAttr(groups, a)
+	a.Value = a.Value.Resolve()
+
+	return a
+}
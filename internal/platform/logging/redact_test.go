@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logRedacted logs attrs through a JSON handler redacting with cfg and returns the decoded record.
+func logRedacted(t *testing.T, cfg RedactConfig, msg string, attrs ...any) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: NewReplaceAttr(RedactOptions(cfg)...)})
+	slog.New(handler).Info(msg, attrs...)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	return record
+}
+
+func TestRedactOptions_Detectors(t *testing.T) {
+	cfg := RedactConfig{Detectors: []string{DetectorEmail, DetectorCard, DetectorIBAN}}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "email", value: "sent to Jane.Doe@example.com", want: "sent to [REDACTED]"},
+		{name: "card with spaces", value: "card 4111 1111 1111 1111 declined", want: "card [REDACTED] declined"},
+		{name: "card with dashes", value: "5500-0000-0000-0004", want: "[REDACTED]"},
+		{name: "digits failing luhn", value: "order 4111111111111112", want: "order 4111111111111112"},
+		{name: "iban", value: "refund to GB82 WEST 1234 5698 7654 32", want: "refund to [REDACTED]"},
+		{name: "iban compact", value: "DE89370400440532013000", want: "[REDACTED]"},
+		{name: "iban failing mod-97", value: "GB82WEST12345698765433", want: "GB82WEST12345698765433"},
+		{name: "no pii", value: "quote 42 created", want: "quote 42 created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := logRedacted(t, cfg, "test", slog.String("detail", tt.value))
+			assert.Equal(t, tt.want, record["detail"])
+		})
+	}
+}
+
+func TestRedactOptions_DetectorsApplyToMessage(t *testing.T) {
+	record := logRedacted(t, RedactConfig{Detectors: []string{DetectorEmail}}, "login failed for bob@example.com")
+	assert.Equal(t, "login failed for [REDACTED]", record[slog.MessageKey])
+}
+
+func TestRedactOptions_FieldsAndPatterns(t *testing.T) {
+	cfg := RedactConfig{
+		Fields:   []string{"ssn"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`cust-\d+`)},
+	}
+
+	record := logRedacted(t, cfg, "test",
+		slog.String("ssn", "123-45-6789"),
+		slog.String("note", "escalated by cust-123456"),
+		slog.String("quote_id", "q-1"),
+	)
+
+	assert.Equal(t, "[REDACTED]", record["ssn"])
+	assert.Equal(t, "escalated by [REDACTED]", record["note"])
+	assert.Equal(t, "q-1", record["quote_id"])
+}
+
+func TestRedactOptions_Styles(t *testing.T) {
+	const card = "4111 1111 1111 1111"
+
+	t.Run("partial keeps last four", func(t *testing.T) {
+		cfg := RedactConfig{Fields: []string{"account"}, Detectors: []string{DetectorCard}, Style: RedactPartial}
+
+		record := logRedacted(t, cfg, "test",
+			slog.String("card", "paid with "+card),
+			slog.String("account", "acct-98765432"),
+		)
+
+		assert.Equal(t, "paid with ****1111", record["card"], "separators are stripped before masking")
+		assert.Equal(t, "****5432", record["account"])
+	})
+
+	t.Run("partial masks short values fully", func(t *testing.T) {
+		record := logRedacted(t, RedactConfig{Fields: []string{"pin"}, Style: RedactPartial}, "test",
+			slog.String("pin", "1234"),
+		)
+
+		assert.Equal(t, "****", record["pin"])
+	})
+
+	t.Run("hash is stable across formatting", func(t *testing.T) {
+		cfg := RedactConfig{Detectors: []string{DetectorCard}, Style: RedactHash, HashKey: "k"}
+
+		first := logRedacted(t, cfg, "test", slog.String("card", card))
+		second := logRedacted(t, cfg, "test", slog.String("card", "4111-1111-1111-1111"))
+
+		assert.Regexp(t, `^hash:[0-9a-f]{16}$`, first["card"])
+		assert.Equal(t, first["card"], second["card"])
+	})
+
+	t.Run("hash key changes the hash", func(t *testing.T) {
+		first := logRedacted(t, RedactConfig{Fields: []string{"email"}, Style: RedactHash, HashKey: "k1"}, "test",
+			slog.String("email", "bob@example.com"),
+		)
+		second := logRedacted(t, RedactConfig{Fields: []string{"email"}, Style: RedactHash, HashKey: "k2"}, "test",
+			slog.String("email", "bob@example.com"),
+		)
+
+		assert.NotEqual(t, first["email"], second["email"])
+		assert.Regexp(t, `^hash:[0-9a-f]{16}$`, second["email"])
+	})
+
+	t.Run("hash without a key masks fully", func(t *testing.T) {
+		record := logRedacted(t, RedactConfig{Fields: []string{"email"}, Style: RedactHash}, "test",
+			slog.String("email", "bob@example.com"),
+		)
+
+		assert.Equal(t, "[REDACTED]", record["email"])
+	})
+}
+
+func TestRedactOptions_BuiltInSecretsStayFullyMasked(t *testing.T) {
+	record := logRedacted(t, RedactConfig{Style: RedactPartial}, "test",
+		slog.String("password", "hunter2-but-longer"),
+	)
+
+	assert.Equal(t, "[REDACTED]", record["password"])
+}
+
+func TestNewWithWriter_AppliesRedactPolicy(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := NewWithWriter(&Config{
+		Level:  "info",
+		Format: "json",
+		Redact: RedactConfig{Detectors: []string{DetectorEmail}, Style: RedactPartial},
+	}, &buf)
+	logger.Info("test", slog.String("customer", "jane@example.com"))
+
+	assert.Contains(t, buf.String(), `"customer":"****.com"`)
+	assert.NotContains(t, buf.String(), "jane@example.com")
+}
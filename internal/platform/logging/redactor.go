@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// Redactor applies the logger's redaction rules to values that are taken
+// apart before logging, such as query parameters, headers and response
+// bodies. A value is redacted as if it were logged as an attribute named by
+// its key, so field names, patterns, detectors and resolved secrets all apply.
+// It is safe for concurrent use.
+type Redactor struct {
+	replaceAttr replaceAttrFunc
+}
+
+// NewRedactor creates a Redactor with the built-in secret rules, the given
+// resolved secrets and the policy, the same rules NewWithWriter applies.
+func NewRedactor(secrets []string, policy RedactConfig) *Redactor {
+	return &Redactor{
+		replaceAttr: NewReplaceAttr(append(SecretRedactOptions(secrets), RedactOptions(policy)...)...),
+	}
+}
+
+// Value returns value redacted as if logged under key. Keys from HTTP are
+// also tried in lower case with dashes as underscores, so a header named
+// X-Api-Key matches the field name x_api_key and Authorization matches
+// authorization.
+func (r *Redactor) Value(key, value string) string {
+	if value == "" {
+		return value
+	}
+
+	redacted := r.redact(key, value)
+	if redacted != value {
+		return redacted
+	}
+
+	if normalized := strings.ReplaceAll(strings.ToLower(key), "-", "_"); normalized != key {
+		return r.redact(normalized, value)
+	}
+
+	return value
+}
+
+// Query returns rawQuery with each parameter value redacted per key.
+// Parameter order is kept, and values that are not redacted keep their
+// original encoding.
+func (r *Redactor) Query(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		rawKey, rawValue, _ := strings.Cut(param, "=")
+
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+
+		if redacted := r.Value(key, value); redacted != value {
+			params[i] = rawKey + "=" + redacted
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// Body returns body redacted for logging. A JSON body has each string value
+// redacted under its object key (nested objects and arrays included) and is
+// re-encoded (numbers are matched as strings too); any other body is
+// redacted as a single value.
+func (r *Redactor) Body(body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber() // Keep card numbers and IDs exact
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return r.Value("body", string(body))
+	}
+
+	out, err := json.Marshal(r.redactJSON("body", doc))
+	if err != nil {
+		return r.Value("body", string(body))
+	}
+
+	return string(out)
+}
+
+// redactJSON redacts the string values in a decoded JSON document.
+func (r *Redactor) redactJSON(key string, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			v[k] = r.redactJSON(k, child)
+		}
+
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = r.redactJSON(key, child)
+		}
+
+		return v
+	case string:
+		return r.Value(key, v)
+	case json.Number:
+		if redacted := r.Value(key, v.String()); redacted != v.String() {
+			return redacted
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// redact applies the redaction rules to a single attribute.
+func (r *Redactor) redact(key, value string) string {
+	a := redactAttr(r.replaceAttr, nil, slog.String(key, value))
+	if s, ok := a.Value.Any().(string); ok {
+		return s
+	}
+
+	return a.Value.String()
+}
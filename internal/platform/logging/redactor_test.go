@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor_Value(t *testing.T) {
+	r := NewRedactor([]string{"s3cr3t"}, RedactConfig{Fields: []string{"x_api_key"}})
+
+	assert.Equal(t, "[REDACTED]", r.Value("password", "hunter2"))
+	assert.Equal(t, "[REDACTED]", r.Value("Authorization", "opaque-value"), "header names are lower-cased")
+	assert.Equal(t, "[REDACTED]", r.Value("X-Api-Key", "k-123"), "dashes match underscores")
+	assert.Equal(t, "[REDACTED]", r.Value("note", "token is s3cr3t"), "resolved secrets match any key")
+	assert.Equal(t, "10", r.Value("limit", "10"))
+	assert.Empty(t, r.Value("token", ""))
+}
+
+func TestRedactor_Query(t *testing.T) {
+	r := NewRedactor(nil, RedactConfig{Detectors: []string{DetectorEmail}})
+
+	assert.Equal(t,
+		"q=hello%20world&api_key=[REDACTED]&token=[REDACTED]&page=2&flag",
+		r.Query("q=hello%20world&api_key=abc123&token=t0k3n&page=2&flag"),
+		"order and encoding of other parameters are kept",
+	)
+	assert.Equal(t, "email=[REDACTED]", r.Query("email=bob%40example.com"), "values are decoded before matching")
+	assert.Empty(t, r.Query(""))
+}
+
+func TestRedactor_Body(t *testing.T) {
+	r := NewRedactor(nil, RedactConfig{Detectors: []string{DetectorCard}, Style: RedactPartial})
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "json keys",
+			body: `{"error":"denied","password":"hunter2-long","details":{"token":"abc"}}`,
+			want: `{"details":{"token":"[REDACTED]"},"error":"denied","password":"[REDACTED]"}`,
+		},
+		{
+			name: "json values and numbers",
+			body: `{"cards":["4111 1111 1111 1111"],"pan":4111111111111111,"count":3}`,
+			want: `{"cards":["****1111"],"count":3,"pan":"****1111"}`,
+		},
+		{
+			name: "plain text",
+			body: "card 4111111111111111 declined",
+			want: "card ****1111 declined",
+		},
+		{
+			name: "not a single json document",
+			body: `{"a":1} {"b":2}`,
+			want: `{"a":1} {"b":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Body([]byte(tt.body)))
+		})
+	}
+}
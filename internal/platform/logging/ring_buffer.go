@@ -0,0 +1,211 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Attribute keys the ring buffer indexes for filtering.
+const (
+	RequestIDKey     = "request_id"
+	CorrelationIDKey = "correlation_id"
+)
+
+// Entry is a log record kept by a RingBuffer. Attrs are redacted and
+// flattened, with group names as dotted key prefixes.
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"msg"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+
+	level slog.Level
+}
+
+// EntryFilter selects entries from a RingBuffer. Zero fields match everything.
+type EntryFilter struct {
+	MinLevel      slog.Leveler // Entries at or above this level; nil matches every level
+	RequestID     string
+	CorrelationID string
+	Since         time.Time // Inclusive
+	Until         time.Time // Exclusive
+	Limit         int       // Most recent matching entries to return
+}
+
+// RingBuffer keeps the most recent log records in memory so they can be
+// inspected without access to the log backend. It is safe for concurrent use.
+type RingBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int // Index the next entry is written to
+	full    bool
+}
+
+// NewRingBuffer creates a buffer holding the last size records.
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{entries: make([]Entry, max(size, 1))}
+}
+
+// Size returns the number of records the buffer holds when full.
+func (b *RingBuffer) Size() int {
+	return len(b.entries)
+}
+
+// add stores e, overwriting the oldest entry when the buffer is full.
+func (b *RingBuffer) add(e Entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = e
+	b.next = (b.next + 1) % len(b.entries)
+
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Query returns the entries matching f, oldest first. With a limit, the
+// most recent matching entries are returned.
+func (b *RingBuffer) Query(f EntryFilter) []Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ordered := b.entries[:b.next]
+	if b.full {
+		ordered = append(slices.Clone(b.entries[b.next:]), b.entries[:b.next]...)
+	}
+
+	matched := make([]Entry, 0)
+
+	for _, e := range ordered {
+		if f.matches(&e) {
+			matched = append(matched, e)
+		}
+	}
+
+	if f.Limit > 0 && len(matched) > f.Limit {
+		matched = matched[len(matched)-f.Limit:]
+	}
+
+	return matched
+}
+
+// matches reports whether e passes every filter set in f.
+func (f *EntryFilter) matches(e *Entry) bool {
+	switch {
+	case f.MinLevel != nil && e.level < f.MinLevel.Level():
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	case f.RequestID != "" && e.Attrs[RequestIDKey] != f.RequestID:
+		return false
+	case f.CorrelationID != "" && e.Attrs[CorrelationIDKey] != f.CorrelationID:
+		return false
+	default:
+		return true
+	}
+}
+
+// ringHandler is the slog.Handler writing to a RingBuffer. Attributes and
+// the message are redacted with replaceAttr, like the other outputs.
+type ringHandler struct {
+	buffer      *RingBuffer
+	replaceAttr replaceAttrFunc
+
+	attrs  map[string]any // Attributes bound via WithAttrs, already redacted
+	groups []string       // Groups opened via WithGroup
+}
+
+// newRingHandler creates a handler storing records in buffer.
+func newRingHandler(buffer *RingBuffer, replaceAttr replaceAttrFunc) *ringHandler {
+	return &ringHandler{buffer: buffer, replaceAttr: replaceAttr}
+}
+
+// Enabled always returns true; the level is applied by the levelHandler.
+func (h *ringHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle stores the record in the buffer.
+func (h *ringHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	attrs := make(map[string]any, len(h.attrs)+r.NumAttrs())
+	maps.Copy(attrs, h.attrs)
+
+	r.Attrs(func(a slog.Attr) bool {
+		h.collect(attrs, h.groups, a)
+		return true
+	})
+
+	msg := redactAttr(h.replaceAttr, nil, slog.String(slog.MessageKey, r.Message))
+
+	h.buffer.add(Entry{
+		Time:    r.Time,
+		Level:   LevelName(r.Level),
+		Message: msg.Value.String(),
+		Attrs:   attrs,
+		level:   r.Level,
+	})
+
+	return nil
+}
+
+// WithAttrs returns a new ringHandler with the given attributes added.
+func (h *ringHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = make(map[string]any, len(h.attrs)+len(attrs))
+	maps.Copy(h2.attrs, h.attrs)
+
+	for _, a := range attrs {
+		h.collect(h2.attrs, h.groups, a)
+	}
+
+	return &h2
+}
+
+// WithGroup returns a new ringHandler with the given group name.
+func (h *ringHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
+}
+
+// collect redacts a and adds it to attrs under its dotted key.
+func (h *ringHandler) collect(attrs map[string]any, groups []string, a slog.Attr) {
+	flattenAttr(h.replaceAttr, groups, a, func(key string, v slog.Value) {
+		attrs[key] = entryValue(v)
+	})
+}
+
+// entryValue converts a resolved slog value to a JSON-friendly value.
+func entryValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+
+		return fmt.Sprint(v.Any())
+	default:
+		return v.Any()
+	}
+}
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBufferedLogger(size int) (*slog.Logger, *RingBuffer) {
+	buffer := NewRingBuffer(size)
+	logger := NewWithWriter(&Config{
+		Level:   "debug",
+		Format:  "json",
+		Service: "test-service",
+		Buffer:  buffer,
+	}, &bytes.Buffer{})
+
+	return logger, buffer
+}
+
+func messages(entries []Entry) []string {
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+
+	return msgs
+}
+
+func TestRingBuffer_KeepsLastN(t *testing.T) {
+	logger, buffer := newBufferedLogger(3)
+
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		logger.Info(msg)
+	}
+
+	assert.Equal(t, []string{"c", "d", "e"}, messages(buffer.Query(EntryFilter{})))
+	assert.Equal(t, []string{"d", "e"}, messages(buffer.Query(EntryFilter{Limit: 2})))
+}
+
+func TestRingBuffer_FiltersByLevelAndIDs(t *testing.T) {
+	logger, buffer := newBufferedLogger(10)
+
+	r1 := logger.With(slog.String(RequestIDKey, "r1"), slog.String(CorrelationIDKey, "c1"))
+	r2 := logger.With(slog.String(RequestIDKey, "r2"), slog.String(CorrelationIDKey, "c1"))
+
+	r1.Debug("r1 debug")
+	r1.Warn("r1 warn")
+	r2.Error("r2 error")
+
+	assert.Equal(t, []string{"r1 warn", "r2 error"}, messages(buffer.Query(EntryFilter{MinLevel: slog.LevelWarn})))
+	assert.Equal(t, []string{"r1 debug", "r1 warn"}, messages(buffer.Query(EntryFilter{RequestID: "r1"})))
+	assert.Len(t, buffer.Query(EntryFilter{CorrelationID: "c1"}), 3)
+	assert.Empty(t, buffer.Query(EntryFilter{CorrelationID: "c2"}))
+}
+
+func TestRingBuffer_FiltersByTimeRange(t *testing.T) {
+	buffer := NewRingBuffer(10)
+	handler := newRingHandler(buffer, nil)
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		r := slog.NewRecord(base.Add(time.Duration(i)*time.Minute), slog.LevelInfo, string(rune('a'+i)), 0)
+		require.NoError(t, handler.Handle(t.Context(), r))
+	}
+
+	got := buffer.Query(EntryFilter{Since: base.Add(time.Minute), Until: base.Add(2 * time.Minute)})
+	assert.Equal(t, []string{"b"}, messages(got))
+}
+
+func TestRingBuffer_StoresRedactedFlattenedAttrs(t *testing.T) {
+	logger, buffer := newBufferedLogger(10)
+
+	logger.WithGroup("request").Info("login",
+		slog.String("password", "hunter2"),
+		slog.Duration("elapsed", time.Second),
+	)
+
+	entries := buffer.Query(EntryFilter{})
+	require.Len(t, entries, 1)
+	assert.Equal(t, "info", entries[0].Level)
+	assert.Equal(t, "test-service", entries[0].Attrs["service_name"])
+	assert.Equal(t, "1s", entries[0].Attrs["request.elapsed"])
+	assert.NotEqual(t, "hunter2", entries[0].Attrs["request.password"])
+}
@@ -0,0 +1,247 @@
+package logging
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultSamplingWindow is used when SamplingConfig.Window is not positive.
+const defaultSamplingWindow = time.Second
+
+// SamplingConfig configures a Sampler.
+type SamplingConfig struct {
+	// Window is the period after which per-key counters and duplicate
+	// detection reset, and a summary of dropped records is logged.
+	Window time.Duration
+
+	// First is the number of records per message key logged in each
+	// window before sampling starts.
+	First int
+
+	// Thereafter logs every Thereafter-th record per message key once
+	// First is exceeded. Zero drops the rest of the window.
+	Thereafter int
+
+	// Dedup drops records identical to one already logged in the window
+	// (same logger attributes, level, message and attributes).
+	Dedup bool
+}
+
+// Sampler drops repetitive info and debug records on high-volume paths.
+// Within each window, the first cfg.First records with the same level and
+// message pass, then every cfg.Thereafter-th; with cfg.Dedup, exact repeats
+// are dropped. A background goroutine ends each window and, if records were
+// dropped, logs a "dropped log records" summary at info with the counts. The
+// summary is not tied to any request, so it carries no trace IDs.
+//
+// Pass it as Config.Sampler and call Close before exit to log the summary of
+// the last window. It is safe for concurrent use.
+type Sampler struct {
+	cfg  SamplingConfig
+	stop chan struct{}
+	done chan struct{} // Closed once the window goroutine has exited
+	once sync.Once
+
+	mu         sync.Mutex
+	summary    slog.Handler // Root handler the dropped-records summary is written to
+	counts     map[string]int
+	seen       map[uint64]struct{}
+	sampled    int
+	duplicates int
+}
+
+// NewSampler creates a sampler and starts its window goroutine.
+func NewSampler(cfg SamplingConfig) *Sampler {
+	s := newSampler(cfg)
+	go s.run()
+
+	return s
+}
+
+// newSampler creates a sampler without starting its window goroutine; tests
+// end windows by calling flush.
+func newSampler(cfg SamplingConfig) *Sampler {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultSamplingWindow
+	}
+
+	return &Sampler{
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		counts: make(map[string]int),
+		seen:   make(map[uint64]struct{}),
+	}
+}
+
+// Close stops the window goroutine and logs the summary of the current
+// window. Records logged afterwards are still sampled, but no longer
+// summarized. Calling it again has no effect.
+func (s *Sampler) Close() {
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.flush()
+	})
+}
+
+// run ends a window every cfg.Window until Close.
+func (s *Sampler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.cfg.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// samplingHandler drops the records its Sampler rejects. Warn and error
+// records, and records logged with a context carrying a WithLevel level,
+// always pass. Every handler derived through WithAttrs and WithGroup shares
+// the sampler, so sampling applies across loggers.
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *Sampler
+
+	// scope fingerprints the attributes and groups bound to this handler,
+	// so identical messages from differently bound loggers are not duplicates.
+	scope uint64
+}
+
+// NewSamplingHandler wraps next with the sampling and deduplication of
+// sampler, and writes the sampler's summaries to next. Wrap one handler per
+// sampler.
+func NewSamplingHandler(next slog.Handler, sampler *Sampler) slog.Handler {
+	sampler.mu.Lock()
+	sampler.summary = next
+	sampler.mu.Unlock()
+
+	return &samplingHandler{next: next, sampler: sampler}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes the record on unless it is sampled out or a duplicate.
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	if r.Level >= slog.LevelWarn {
+		return h.next.Handle(ctx, r)
+	}
+
+	// Requests with verbose logging turned on (see WithLevel) are never sampled
+	if _, ok := LevelFromContext(ctx); ok {
+		return h.next.Handle(ctx, r)
+	}
+
+	if !h.sampler.admit(h.scope, &r) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a new samplingHandler with the given attributes added.
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	scope := fnv.New64a()
+	_, _ = scope.Write(binary.LittleEndian.AppendUint64(nil, h.scope))
+
+	for _, a := range attrs {
+		_, _ = scope.Write([]byte(a.String()))
+	}
+
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler, scope: scope.Sum64()}
+}
+
+// WithGroup returns a new samplingHandler with the given group name.
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	scope := fnv.New64a()
+	_, _ = scope.Write(binary.LittleEndian.AppendUint64(nil, h.scope))
+	_, _ = scope.Write([]byte("group:" + name))
+
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler, scope: scope.Sum64()}
+}
+
+// admit decides whether r is kept in the current window.
+func (s *Sampler) admit(scope uint64, r *slog.Record) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cfg.Dedup {
+		fp := fingerprint(scope, r)
+		if _, ok := s.seen[fp]; ok {
+			s.duplicates++
+			return false
+		}
+
+		s.seen[fp] = struct{}{}
+	}
+
+	key := r.Level.String() + "|" + r.Message
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.cfg.First {
+		return true
+	}
+
+	if s.cfg.Thereafter > 0 && (n-s.cfg.First)%s.cfg.Thereafter == 0 {
+		return true
+	}
+
+	s.sampled++
+
+	return false
+}
+
+// flush ends the current window, resetting the counters and duplicate
+// detection, and logs a summary if the window dropped anything.
+func (s *Sampler) flush() {
+	s.mu.Lock()
+	summary, sampled, duplicates := s.summary, s.sampled, s.duplicates
+	s.sampled, s.duplicates = 0, 0
+	clear(s.counts)
+	clear(s.seen)
+	s.mu.Unlock()
+
+	if summary == nil || sampled+duplicates == 0 {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "dropped log records", 0)
+	r.AddAttrs(
+		slog.Int("dropped", sampled+duplicates),
+		slog.Int("sampled", sampled),
+		slog.Int("duplicates", duplicates),
+		slog.Duration("window", s.cfg.Window),
+	)
+
+	_ = summary.Handle(context.Background(), r) // Best effort, like the records it summarizes
+}
+
+// fingerprint hashes the identity of a record for duplicate detection.
+func fingerprint(scope uint64, r *slog.Record) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(binary.LittleEndian.AppendUint64(nil, scope))
+	_, _ = h.Write([]byte(r.Level.String()))
+	_, _ = h.Write([]byte(r.Message))
+
+	r.Attrs(func(a slog.Attr) bool {
+		_, _ = h.Write([]byte(a.String()))
+		return true
+	})
+
+	return h.Sum64()
+}
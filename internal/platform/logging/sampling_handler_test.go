@@ -0,0 +1,195 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingHandler collects handled records for assertions.
+type recordingHandler struct {
+	mu      *sync.Mutex
+	records *[]slog.Record
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{mu: new(sync.Mutex), records: new([]slog.Record)}
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	*h.records = append(*h.records, r)
+
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordingHandler) WithGroup(string) slog.Handler { return h }
+
+func (h *recordingHandler) messages() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	msgs := make([]string, 0, len(*h.records))
+	for _, r := range *h.records {
+		msgs = append(msgs, r.Message)
+	}
+
+	return msgs
+}
+
+// newTestSampler returns a logger sampled by a sampler whose windows end
+// only when the test calls flush.
+func newTestSampler(cfg SamplingConfig) (*slog.Logger, *recordingHandler, *Sampler) {
+	rec := newRecordingHandler()
+	sampler := newSampler(cfg)
+
+	return slog.New(NewSamplingHandler(rec, sampler)), rec, sampler
+}
+
+func TestSamplingHandler_FirstThenEveryMth(t *testing.T) {
+	logger, rec, _ := newTestSampler(SamplingConfig{Window: time.Second, First: 2, Thereafter: 3})
+
+	for range 10 {
+		logger.Info("request completed")
+	}
+
+	// 1, 2 pass (first); then 5 and 8 (every 3rd after first)
+	assert.Len(t, rec.messages(), 4)
+}
+
+func TestSamplingHandler_KeysByLevelAndMessage(t *testing.T) {
+	logger, rec, _ := newTestSampler(SamplingConfig{Window: time.Second, First: 1})
+
+	logger.Info("a")
+	logger.Info("a")
+	logger.Info("b")
+	logger.Debug("a")
+
+	assert.Equal(t, []string{"a", "b", "a"}, rec.messages())
+}
+
+func TestSamplingHandler_WarnAndErrorAlwaysPass(t *testing.T) {
+	logger, rec, _ := newTestSampler(SamplingConfig{Window: time.Second, First: 0, Dedup: true})
+
+	for range 5 {
+		logger.Warn("slow downstream")
+		logger.Error("downstream failed")
+		logger.Info("dropped")
+	}
+
+	assert.Len(t, rec.messages(), 10)
+}
+
+func TestSamplingHandler_DedupDropsIdenticalRecords(t *testing.T) {
+	logger, rec, _ := newTestSampler(SamplingConfig{Window: time.Second, First: 100, Dedup: true})
+
+	logger.Info("cache miss", slog.String("key", "a"))
+	logger.Info("cache miss", slog.String("key", "a"))
+	logger.Info("cache miss", slog.String("key", "b"))
+	logger.With(slog.String("request_id", "r2")).Info("cache miss", slog.String("key", "a"))
+
+	assert.Len(t, rec.messages(), 3, "only the exact repeat is a duplicate")
+}
+
+func TestSamplingHandler_SummaryAfterWindow(t *testing.T) {
+	logger, rec, sampler := newTestSampler(SamplingConfig{Window: time.Second, First: 1, Dedup: true})
+
+	logger.Info("hit", slog.Int("n", 1))
+	logger.Info("hit", slog.Int("n", 1)) // duplicate
+	logger.Info("hit", slog.Int("n", 2)) // sampled
+	logger.Info("hit", slog.Int("n", 3)) // sampled
+
+	sampler.flush()
+	logger.Info("hit", slog.Int("n", 4))
+
+	require.Equal(t, []string{"hit", "dropped log records", "hit"}, rec.messages())
+
+	attrs := map[string]slog.Value{}
+	(*rec.records)[1].Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+	assert.Equal(t, int64(3), attrs["dropped"].Int64())
+	assert.Equal(t, int64(2), attrs["sampled"].Int64())
+	assert.Equal(t, int64(1), attrs["duplicates"].Int64())
+
+	// A window with no drops emits no summary
+	sampler.flush()
+	logger.Info("hit", slog.Int("n", 5))
+	assert.Len(t, rec.messages(), 4)
+}
+
+func TestSamplingHandler_ContextLevelBypassesSampling(t *testing.T) {
+	logger, rec, _ := newTestSampler(SamplingConfig{Window: time.Second, First: 0, Dedup: true})
+	ctx := WithLevel(t.Context(), LevelTrace)
+
+	for range 3 {
+		logger.InfoContext(ctx, "debugged request")
+		logger.Info("sampled")
+	}
+
+	assert.Equal(t, []string{"debugged request", "debugged request", "debugged request"}, rec.messages())
+}
+
+func TestSampler_SummaryWithoutNextRecord(t *testing.T) {
+	rec := newRecordingHandler()
+	sampler := NewSampler(SamplingConfig{Window: 10 * time.Millisecond, First: 1})
+	t.Cleanup(sampler.Close)
+
+	logger := slog.New(NewSamplingHandler(rec, sampler))
+	logger.Info("hit")
+	logger.Info("hit")
+
+	// The window goroutine logs the summary with no further records
+	require.Eventually(t, func() bool {
+		return slices.Contains(rec.messages(), "dropped log records")
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSampler_CloseLogsLastWindow(t *testing.T) {
+	var buf bytes.Buffer
+
+	sampler := NewSampler(SamplingConfig{Window: time.Hour, First: 1})
+	logger := NewWithWriter(&Config{
+		Level:   "info",
+		Format:  "json",
+		Service: "test-service",
+		Sampler: sampler,
+	}, &buf)
+
+	// A sampled span in the dropped record's context must not leak into the summary
+	ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.InfoContext(ctx, "request started")
+	logger.InfoContext(ctx, "request started")
+	require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	sampler.Close()
+	sampler.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "trace_id")
+	assert.Contains(t, lines[1], `"msg":"dropped log records"`)
+	assert.Contains(t, lines[1], `"dropped":1`)
+	assert.NotContains(t, lines[1], "trace_id")
+}
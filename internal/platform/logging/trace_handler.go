@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys added by the trace handler.
+const (
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceFlagsKey = "trace_flags"
+)
+
+// traceHandler adds the active OpenTelemetry span of each record's context
+// as trace_id, span_id and trace_flags attributes. Records logged without a
+// context (Info rather than InfoContext) or outside a span are unchanged.
+type traceHandler struct {
+	next slog.Handler
+}
+
+// NewTraceHandler wraps next so every record logged with a context carrying
+// a valid span context gets trace_id, span_id and trace_flags attributes.
+// Like other record attributes, they are nested under any open group.
+func NewTraceHandler(next slog.Handler) slog.Handler {
+	return &traceHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *traceHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the span context attributes, if any, and passes the record on.
+func (h *traceHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface requires value
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r = r.Clone() // Do not share attrs with the caller's record
+		r.AddAttrs(
+			slog.String(TraceIDKey, sc.TraceID().String()),
+			slog.String(SpanIDKey, sc.SpanID().String()),
+			slog.String(TraceFlagsKey, sc.TraceFlags().String()),
+		)
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a new traceHandler with the given attributes added.
+func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &traceHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new traceHandler with the given group name.
+func (h *traceHandler) WithGroup(name string) slog.Handler {
+	return &traceHandler{next: h.next.WithGroup(name)}
+}
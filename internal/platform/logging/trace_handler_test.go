@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	return trace.ContextWithSpanContext(t.Context(), sc), sc
+}
+
+func TestTraceHandler_AddsSpanContext(t *testing.T) {
+	ctx, sc := spanContext(t)
+
+	for _, format := range []string{"json", "text", "pretty"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := NewWithWriter(&Config{Level: "info", Format: format}, &buf)
+
+			logger.InfoContext(ctx, "with span")
+
+			assert.Contains(t, buf.String(), sc.TraceID().String())
+			assert.Contains(t, buf.String(), sc.SpanID().String())
+			assert.Contains(t, buf.String(), TraceFlagsKey)
+		})
+	}
+}
+
+func TestTraceHandler_JSONFields(t *testing.T) {
+	ctx, sc := spanContext(t)
+
+	var buf bytes.Buffer
+	logger := NewWithWriter(&Config{Level: "info", Format: "json"}, &buf)
+
+	logger.InfoContext(ctx, "with span")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, sc.TraceID().String(), entry[TraceIDKey])
+	assert.Equal(t, sc.SpanID().String(), entry[SpanIDKey])
+	assert.Equal(t, "01", entry[TraceFlagsKey])
+}
+
+func TestTraceHandler_FileOutput(t *testing.T) {
+	ctx, sc := spanContext(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	var buf bytes.Buffer
+	logger := NewWithWriter(&Config{
+		Level:  "info",
+		Format: "pretty",
+		File:   FileConfig{Enabled: true, Path: path, MaxSizeMB: 1},
+	}, &buf)
+
+	logger.InfoContext(ctx, "with span")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"trace_id":"`+sc.TraceID().String()+`"`)
+	assert.Contains(t, buf.String(), sc.TraceID().String())
+}
+
+func TestTraceHandler_NoSpanNoFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&Config{Level: "info", Format: "json"}, &buf)
+
+	logger.InfoContext(t.Context(), "no span")
+	logger.Info("no context")
+
+	assert.NotContains(t, buf.String(), TraceIDKey)
+	assert.NotContains(t, buf.String(), SpanIDKey)
+}
@@ -0,0 +1,280 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// Exporter selects where telemetry is sent.
+type Exporter string
+
+// Supported exporters for Config.Exporter.
+const (
+	ExporterOTLPGRPC Exporter = "otlp-grpc" // OTLP over gRPC to Config.Endpoint (default)
+	ExporterOTLPHTTP Exporter = "otlp-http" // OTLP over HTTP/protobuf to Config.Endpoint
+	ExporterStdout   Exporter = "stdout"    // JSON spans (and optionally metrics) on stdout
+	ExporterFile     Exporter = "file"      // JSON spans and metrics appended to a file
+)
+
+// OTLP/HTTP paths of each signal, appended to the endpoint path.
+const (
+	tracesPath  = "/v1/traces"
+	metricsPath = "/v1/metrics"
+	logsPath    = "/v1/logs"
+)
+
+// Permission modes for the telemetry file and its directory.
+const (
+	telemetryFilePerms = 0o640
+	telemetryDirPerms  = 0o750
+)
+
+// OTLPConfig holds options shared by the otlp-grpc and otlp-http exporters.
+type OTLPConfig struct {
+	Compression string        // "gzip" or "none" (default)
+	Timeout     time.Duration // Per export request; zero uses the exporter default (10s)
+}
+
+// StdoutConfig holds options for the stdout exporter.
+type StdoutConfig struct {
+	Pretty  bool // Indent JSON output
+	Metrics bool // Also print metrics every MetricsInterval; spans only otherwise
+}
+
+// FileConfig holds options for the file exporter.
+type FileConfig struct {
+	Path   string // File spans and metrics are appended to
+	Pretty bool   // Indent JSON output
+}
+
+// exporters are the exporters selected by Config.Exporter. Metrics and logs
+// are nil when the exporter does not export them.
+type exporters struct {
+	spans   trace.SpanProcessor
+	metrics metric.Exporter
+	logs    sdklog.Exporter
+	closer  io.Closer // Output to close after the providers shut down, if any
+}
+
+// newExporters creates the exporters selected by cfg.Exporter.
+func newExporters(ctx context.Context, cfg *Config) (*exporters, error) {
+	switch cfg.Exporter {
+	case ExporterOTLPGRPC, "":
+		return newGRPCExporters(ctx, cfg)
+	case ExporterOTLPHTTP:
+		return newHTTPExporters(ctx, cfg)
+	case ExporterStdout:
+		return newWriterExporters(os.Stdout, cfg.Stdout.Pretty, cfg.Stdout.Metrics, nil)
+	case ExporterFile:
+		return newFileExporters(cfg.File)
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// newGRPCExporters creates OTLP/gRPC exporters.
+func newGRPCExporters(ctx context.Context, cfg *Config) (*exporters, error) {
+	settings, err := newExportSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	traceOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpointURL(settings.endpoint),
+		otlptracegrpc.WithHeaders(settings.headers),
+	}
+	metricOpts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpointURL(settings.endpoint),
+		otlpmetricgrpc.WithHeaders(settings.headers),
+	}
+	logOpts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpointURL(settings.endpoint),
+		otlploggrpc.WithHeaders(settings.headers),
+	}
+
+	if settings.tlsConfig != nil {
+		creds := credentials.NewTLS(settings.tlsConfig)
+		traceOpts = append(traceOpts, otlptracegrpc.WithTLSCredentials(creds))
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithTLSCredentials(creds))
+		logOpts = append(logOpts, otlploggrpc.WithTLSCredentials(creds))
+	}
+
+	if cfg.OTLP.Compression == "gzip" {
+		traceOpts = append(traceOpts, otlptracegrpc.WithCompressor("gzip"))
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithCompressor("gzip"))
+		logOpts = append(logOpts, otlploggrpc.WithCompressor("gzip"))
+	}
+
+	if timeout := cfg.OTLP.Timeout; timeout > 0 {
+		traceOpts = append(traceOpts, otlptracegrpc.WithTimeout(timeout))
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithTimeout(timeout))
+		logOpts = append(logOpts, otlploggrpc.WithTimeout(timeout))
+	}
+
+	spanExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating metric exporter: %w", err)
+	}
+
+	exp := &exporters{
+		spans:   trace.NewBatchSpanProcessor(spanExporter),
+		metrics: metricExporter,
+	}
+
+	if cfg.Logs {
+		exp.logs, err = otlploggrpc.New(ctx, logOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating log exporter: %w", err)
+		}
+	}
+
+	return exp, nil
+}
+
+// newHTTPExporters creates OTLP/HTTP exporters. Each signal is posted to its
+// standard path (/v1/traces, ...) under the endpoint path.
+func newHTTPExporters(ctx context.Context, cfg *Config) (*exporters, error) {
+	settings, err := newExportSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	traceOpts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(settings.host),
+		otlptracehttp.WithURLPath(settings.basePath + tracesPath),
+		otlptracehttp.WithHeaders(settings.headers),
+	}
+	metricOpts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(settings.host),
+		otlpmetrichttp.WithURLPath(settings.basePath + metricsPath),
+		otlpmetrichttp.WithHeaders(settings.headers),
+	}
+	logOpts := []otlploghttp.Option{
+		otlploghttp.WithEndpoint(settings.host),
+		otlploghttp.WithURLPath(settings.basePath + logsPath),
+		otlploghttp.WithHeaders(settings.headers),
+	}
+
+	if settings.tlsConfig != nil {
+		traceOpts = append(traceOpts, otlptracehttp.WithTLSClientConfig(settings.tlsConfig))
+		metricOpts = append(metricOpts, otlpmetrichttp.WithTLSClientConfig(settings.tlsConfig))
+		logOpts = append(logOpts, otlploghttp.WithTLSClientConfig(settings.tlsConfig))
+	} else {
+		traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+		logOpts = append(logOpts, otlploghttp.WithInsecure())
+	}
+
+	if cfg.OTLP.Compression == "gzip" {
+		traceOpts = append(traceOpts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		metricOpts = append(metricOpts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		logOpts = append(logOpts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	if timeout := cfg.OTLP.Timeout; timeout > 0 {
+		traceOpts = append(traceOpts, otlptracehttp.WithTimeout(timeout))
+		metricOpts = append(metricOpts, otlpmetrichttp.WithTimeout(timeout))
+		logOpts = append(logOpts, otlploghttp.WithTimeout(timeout))
+	}
+
+	spanExporter, err := otlptracehttp.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating metric exporter: %w", err)
+	}
+
+	exp := &exporters{
+		spans:   trace.NewBatchSpanProcessor(spanExporter),
+		metrics: metricExporter,
+	}
+
+	if cfg.Logs {
+		exp.logs, err = otlploghttp.New(ctx, logOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating log exporter: %w", err)
+		}
+	}
+
+	return exp, nil
+}
+
+// newFileExporters creates exporters appending JSON to the file at cfg.Path,
+// creating its directory if needed.
+func newFileExporters(cfg FileConfig) (*exporters, error) {
+	if dir := filepath.Dir(cfg.Path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, telemetryDirPerms); err != nil {
+			return nil, fmt.Errorf("creating telemetry directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, telemetryFilePerms)
+	if err != nil {
+		return nil, fmt.Errorf("opening telemetry file: %w", err)
+	}
+
+	exp, err := newWriterExporters(f, cfg.Pretty, true, f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return exp, nil
+}
+
+// newWriterExporters creates exporters writing JSON to w. Spans are written
+// as they end, so they show up while the request is fresh. Logs are not
+// exported: the application log already goes to the console and log files.
+func newWriterExporters(w io.Writer, pretty, metrics bool, closer io.Closer) (*exporters, error) {
+	traceOpts := []stdouttrace.Option{stdouttrace.WithWriter(w)}
+	metricOpts := []stdoutmetric.Option{stdoutmetric.WithWriter(w)}
+
+	if pretty {
+		traceOpts = append(traceOpts, stdouttrace.WithPrettyPrint())
+		metricOpts = append(metricOpts, stdoutmetric.WithPrettyPrint())
+	}
+
+	spanExporter, err := stdouttrace.New(traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	exp := &exporters{
+		spans:  trace.NewSimpleSpanProcessor(spanExporter),
+		closer: closer,
+	}
+
+	if metrics {
+		exp.metrics, err = stdoutmetric.New(metricOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating metric exporter: %w", err)
+		}
+	}
+
+	return exp, nil
+}
@@ -0,0 +1,124 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// httpRequest is an export request received by the OTLP/HTTP stand-in.
+type httpRequest struct {
+	path     string
+	auth     string
+	encoding string
+}
+
+func TestNew_OTLPHTTP(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []httpRequest
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, httpRequest{
+			path:     r.URL.Path,
+			auth:     r.Header.Get("Authorization"),
+			encoding: r.Header.Get("Content-Encoding"),
+		})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	provider, err := New(context.Background(), &Config{
+		Enabled:      true,
+		Exporter:     ExporterOTLPHTTP,
+		Endpoint:     srv.URL + "/otlp/",
+		ServiceName:  "test-service",
+		SamplingRate: 1,
+		BearerToken:  "token",
+		OTLP:         OTLPConfig{Compression: "gzip"},
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	paths := make([]string, 0, len(received))
+	for _, r := range received {
+		paths = append(paths, r.path)
+		assert.Equal(t, "Bearer token", r.auth)
+		assert.Equal(t, "gzip", r.encoding)
+	}
+
+	assert.Contains(t, paths, "/otlp/v1/traces")
+	assert.Contains(t, paths, "/otlp/v1/metrics")
+}
+
+func TestNew_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry", "out.json")
+
+	provider, err := New(context.Background(), &Config{
+		Enabled:      true,
+		Exporter:     ExporterFile,
+		ServiceName:  "test-service",
+		SamplingRate: 1,
+		File:         FileConfig{Path: path, Pretty: true},
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "file-operation")
+	span.End()
+
+	counter, err := otel.Meter("test").Int64Counter("test.counter")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	out := string(data)
+	assert.Contains(t, out, `"Name": "file-operation"`)
+	assert.Contains(t, out, `"Name": "test.counter"`)
+	assert.Contains(t, out, "\n\t\"Name\"", "pretty output is indented")
+}
+
+func TestNew_UnknownExporter(t *testing.T) {
+	_, err := New(context.Background(), &Config{
+		Enabled:     true,
+		Exporter:    "zipkin",
+		ServiceName: "test-service",
+	})
+	require.ErrorContains(t, err, `unknown exporter "zipkin"`)
+}
+
+func TestNewWriterExporters_SpansOnly(t *testing.T) {
+	var buf strings.Builder
+
+	exp, err := newWriterExporters(&buf, false, false, nil)
+	require.NoError(t, err)
+
+	assert.NotNil(t, exp.spans)
+	assert.Nil(t, exp.metrics)
+	assert.Nil(t, exp.logs)
+	assert.Nil(t, exp.closer)
+}
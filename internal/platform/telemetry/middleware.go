@@ -15,6 +15,12 @@ const (
 	instrumentationName = "github.com/jsamuelsen/go-service-template/telemetry"
 )
 
+// DurationBuckets are the histogram bucket boundaries, in seconds, for
+// request durations. They follow the OpenTelemetry HTTP semantic conventions;
+// the SDK defaults are meant for milliseconds and put every request in the
+// first few buckets.
+var DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
 // Metrics holds HTTP server metrics.
 type Metrics struct {
 	requestDuration metric.Float64Histogram
@@ -30,6 +36,7 @@ func NewMetrics() (*Metrics, error) {
 		"http.server.request.duration",
 		metric.WithDescription("HTTP request duration in seconds"),
 		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(DurationBuckets...),
 	)
 	if err != nil {
 		return nil, err
@@ -58,8 +65,9 @@ func NewMetrics() (*Metrics, error) {
 	}, nil
 }
 
-// Middleware returns Gin middleware for OpenTelemetry tracing and metrics.
-// Uses otelgin for tracing and adds custom metrics and X-Trace-ID header.
+// Middleware returns Gin middleware recording HTTP server metrics and
+// setting the X-Trace-ID response header. Install it after TracingMiddleware,
+// which starts the server span.
 func Middleware(serviceName string) gin.HandlerFunc {
 	// Create metrics - errors are logged but don't prevent the middleware from working
 	metrics, err := NewMetrics()
@@ -81,15 +89,15 @@ func Middleware(serviceName string) gin.HandlerFunc {
 			defer metrics.activeRequests.Add(c.Request.Context(), -1, metric.WithAttributes(attrs...))
 		}
 
-		// Process request
-		c.Next()
-
-		// Get trace ID from span and add to response header
+		// Add trace ID to the response header before the handler writes it
 		span := trace.SpanFromContext(c.Request.Context())
 		if span.SpanContext().HasTraceID() {
 			c.Header("X-Trace-ID", span.SpanContext().TraceID().String())
 		}
 
+		// Process request
+		c.Next()
+
 		// Record metrics
 		if metrics != nil {
 			duration := time.Since(start).Seconds()
@@ -104,8 +112,9 @@ func Middleware(serviceName string) gin.HandlerFunc {
 	}
 }
 
-// TracingMiddleware returns just the otelgin tracing middleware.
-// Use this if you want tracing without custom metrics.
+// TracingMiddleware returns the otelgin middleware, which starts a server
+// span for each request, continuing the trace of an incoming traceparent
+// header, and puts it in the request context.
 func TracingMiddleware(serviceName string) gin.HandlerFunc {
 	return otelgin.Middleware(serviceName)
 }
@@ -1,19 +1,22 @@
-// Package telemetry provides OpenTelemetry tracing and metrics.
+// Package telemetry provides OpenTelemetry tracing, metrics and log export.
 package telemetry
 
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
 )
 
 // shutdownTimeout is the timeout for graceful telemetry provider shutdown.
@@ -22,23 +25,65 @@ const shutdownTimeout = 5 * time.Second
 // Config holds telemetry configuration.
 type Config struct {
 	Enabled      bool
-	Endpoint     string
+	Endpoint     string // OTLP collector URL; http is plaintext, https is TLS
 	ServiceName  string
 	Version      string
 	Environment  string
 	SamplingRate float64
+
+	// Sampling refines sampling by route, rate limit and errors.
+	Sampling SamplingConfig
+
+	// Exporter selects where telemetry is sent; empty means ExporterOTLPGRPC.
+	Exporter Exporter
+
+	// Logs enables OTLP log export. Records reach the exporter through the
+	// global LoggerProvider (see logging.Config.LoggerProvider). The stdout
+	// and file exporters do not export logs.
+	Logs bool
+
+	// TLS configures the connection to an https Endpoint. An http Endpoint
+	// is plaintext and ignores it.
+	TLS TLSConfig
+
+	// Headers are sent with every export request, e.g. a collector API key.
+	Headers map[string]string
+
+	// BearerToken, if set, is sent as "authorization: Bearer <token>".
+	BearerToken string
+
+	// OTLP holds options for the otlp-grpc and otlp-http exporters.
+	OTLP OTLPConfig
+
+	// Stdout holds options for the stdout exporter.
+	Stdout StdoutConfig
+
+	// File holds options for the file exporter.
+	File FileConfig
+
+	// MetricsInterval is how often metrics are exported; zero uses the SDK
+	// default (60s).
+	MetricsInterval time.Duration
+
+	// Prometheus, if set, registers a Prometheus exporter reading the
+	// MeterProvider, so a promhttp handler for the registry serves every
+	// instrument. It works even when Enabled is false, in which case only
+	// the MeterProvider is set up.
+	Prometheus prometheus.Registerer
 }
 
 // Provider holds the OpenTelemetry providers and provides a Shutdown method.
 type Provider struct {
 	tracerProvider *trace.TracerProvider
 	meterProvider  *metric.MeterProvider
+	loggerProvider *sdklog.LoggerProvider
+	closer         io.Closer // Exporter output, closed after the providers
 }
 
 // New creates and configures OpenTelemetry providers.
-// Returns a noop provider if telemetry is disabled.
+// Returns a noop provider if telemetry is disabled and Prometheus is nil.
 func New(ctx context.Context, cfg *Config) (*Provider, error) {
-	if !cfg.Enabled {
+	if !cfg.Enabled && cfg.Prometheus == nil {
 		return &Provider{}, nil
 	}
 
@@ -49,49 +94,80 @@ func New(ctx context.Context, cfg *Config) (*Provider, error) {
 			semconv.SchemaURL,
 			semconv.ServiceName(cfg.ServiceName),
 			semconv.ServiceVersion(cfg.Version),
-			semconv.DeploymentEnvironment(cfg.Environment),
+			semconv.DeploymentEnvironmentName(cfg.Environment),
 		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("creating resource: %w", err)
 	}
 
-	// Create trace exporter
-	traceExporter, err := otlptracegrpc.New(ctx,
-		otlptracegrpc.WithEndpoint(cfg.Endpoint),
-		otlptracegrpc.WithInsecure(), // TODO: Configure TLS for production
-	)
+	meterOpts := []metric.Option{metric.WithResource(res)}
+
+	// Register the Prometheus exporter; it reads the meter provider on scrape
+	if cfg.Prometheus != nil {
+		reader, err := otelprom.New(otelprom.WithRegisterer(cfg.Prometheus))
+		if err != nil {
+			return nil, fmt.Errorf("creating prometheus exporter: %w", err)
+		}
+
+		meterOpts = append(meterOpts, metric.WithReader(reader))
+	}
+
+	// Metrics only: no exporters, tracing or logs
+	if !cfg.Enabled {
+		meterProvider := metric.NewMeterProvider(meterOpts...)
+		otel.SetMeterProvider(meterProvider)
+
+		return &Provider{meterProvider: meterProvider}, nil
+	}
+
+	// Create the exporters selected by cfg.Exporter
+	exp, err := newExporters(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("creating trace exporter: %w", err)
+		return nil, fmt.Errorf("configuring %s exporter: %w", cfg.Exporter, err)
 	}
 
 	// Create tracer provider with sampler
-	sampler := trace.ParentBased(trace.TraceIDRatioBased(cfg.SamplingRate))
+	spans := exp.spans
+	if cfg.Sampling.KeepErrors {
+		spans = keepErrorsProcessor{spans}
+	}
+
 	tracerProvider := trace.NewTracerProvider(
 		trace.WithResource(res),
-		trace.WithBatcher(traceExporter),
-		trace.WithSampler(sampler),
+		trace.WithSpanProcessor(spans),
+		trace.WithSampler(newSampler(cfg.SamplingRate, cfg.Sampling)),
 	)
 
-	// Create metric exporter
-	metricExporter, err := otlpmetricgrpc.New(ctx,
-		otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
-		otlpmetricgrpc.WithInsecure(), // TODO: Configure TLS for production
-	)
-	if err != nil {
-		return nil, fmt.Errorf("creating metric exporter: %w", err)
+	// Create meter provider; without a metric exporter or Prometheus, instruments record nothing
+	if exp.metrics != nil {
+		var readerOpts []metric.PeriodicReaderOption
+		if cfg.MetricsInterval > 0 {
+			readerOpts = append(readerOpts, metric.WithInterval(cfg.MetricsInterval))
+		}
+
+		meterOpts = append(meterOpts, metric.WithReader(metric.NewPeriodicReader(exp.metrics, readerOpts...)))
 	}
 
-	// Create meter provider
-	meterProvider := metric.NewMeterProvider(
-		metric.WithResource(res),
-		metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-	)
+	meterProvider := metric.NewMeterProvider(meterOpts...)
+
+	// Create logger provider
+	var loggerProvider *sdklog.LoggerProvider
+	if exp.logs != nil {
+		loggerProvider = sdklog.NewLoggerProvider(
+			sdklog.WithResource(res),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(exp.logs)),
+		)
+	}
 
 	// Set global providers
 	otel.SetTracerProvider(tracerProvider)
 	otel.SetMeterProvider(meterProvider)
 
+	if loggerProvider != nil {
+		global.SetLoggerProvider(loggerProvider)
+	}
+
 	// Set global propagator (W3C TraceContext + Baggage)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
@@ -101,6 +177,8 @@ func New(ctx context.Context, cfg *Config) (*Provider, error) {
 	return &Provider{
 		tracerProvider: tracerProvider,
 		meterProvider:  meterProvider,
+		loggerProvider: loggerProvider,
+		closer:         exp.closer,
 	}, nil
 }
 
@@ -130,6 +208,19 @@ func (p *Provider) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if p.loggerProvider != nil {
+		err := p.loggerProvider.Shutdown(shutdownCtx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shutting down logger provider: %w", err))
+		}
+	}
+
+	if p.closer != nil {
+		if err := p.closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing exporter output: %w", err))
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("telemetry shutdown errors: %v", errs)
 	}
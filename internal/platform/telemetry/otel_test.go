@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// scrape returns the text exposition of the registry.
+func scrape(t *testing.T, registry *prometheus.Registry) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/-/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+func TestNew_PrometheusWithTelemetryDisabled(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	provider, err := New(context.Background(), &Config{
+		ServiceName: "test-service",
+		Prometheus:  registry,
+	})
+	require.NoError(t, err)
+
+	defer func() { require.NoError(t, provider.Shutdown(context.Background())) }()
+
+	histogram, err := otel.Meter("test").Float64Histogram(
+		"http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.1, 0.5),
+	)
+	require.NoError(t, err)
+	histogram.Record(context.Background(), 0.2, metric.WithAttributes(attribute.String("http.route", "/items")))
+
+	out := scrape(t, registry)
+	assert.Contains(t, out, "# TYPE http_server_request_duration_seconds histogram")
+	assert.Contains(t, out, `http_server_request_duration_seconds_bucket{http_route="/items"`)
+	assert.Contains(t, out, `le="0.5"`)
+	assert.Contains(t, out, `service_name="test-service"`, "resource is exposed as target_info")
+}
+
+func TestNew_DisabledWithoutPrometheusIsNoop(t *testing.T) {
+	provider, err := New(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	assert.Nil(t, provider.meterProvider)
+	require.NoError(t, provider.Shutdown(context.Background()))
+}
@@ -0,0 +1,232 @@
+package telemetry
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SamplingConfig refines the head sampling of Config.SamplingRate.
+type SamplingConfig struct {
+	// Routes overrides the sampling ratio of server spans by request path
+	// prefix; the longest matching prefix wins. A ratio of zero never
+	// samples the route, even under a sampled parent (e.g. "/-/" health
+	// checks).
+	Routes map[string]float64
+
+	// RateLimit caps the traces started per second; zero is unlimited.
+	// Spans joining a sampled parent are not limited.
+	RateLimit float64
+
+	// KeepErrors records spans the head sampler drops and exports those that
+	// end with an error status. Only the error spans are exported, not the
+	// rest of their trace.
+	KeepErrors bool
+}
+
+// routeRatio is the sampling ratio of a path prefix.
+type routeRatio struct {
+	prefix  string
+	ratio   float64
+	sampler trace.Sampler
+}
+
+// newRoutes sorts the route ratios longest prefix first.
+func newRoutes(routes map[string]float64) []routeRatio {
+	out := make([]routeRatio, 0, len(routes))
+	for prefix, ratio := range routes {
+		out = append(out, routeRatio{
+			prefix:  prefix,
+			ratio:   ratio,
+			sampler: trace.TraceIDRatioBased(ratio),
+		})
+	}
+
+	slices.SortFunc(out, func(a, b routeRatio) int {
+		return cmp.Or(cmp.Compare(len(b.prefix), len(a.prefix)), strings.Compare(a.prefix, b.prefix))
+	})
+
+	return out
+}
+
+// matchRoute returns the route of a server span's url.path attribute.
+func matchRoute(routes []routeRatio, p *trace.SamplingParameters) (routeRatio, bool) {
+	if p.Kind != oteltrace.SpanKindServer || len(routes) == 0 {
+		return routeRatio{}, false
+	}
+
+	for _, attr := range p.Attributes {
+		if attr.Key != semconv.URLPathKey {
+			continue
+		}
+
+		path := attr.Value.AsString()
+		for _, r := range routes {
+			if strings.HasPrefix(path, r.prefix) {
+				return r, true
+			}
+		}
+
+		break
+	}
+
+	return routeRatio{}, false
+}
+
+// newSampler builds the sampler: routes with a zero ratio are dropped, root
+// spans are sampled by route ratio (rate otherwise) within the rate limit,
+// and other spans follow their parent.
+func newSampler(rate float64, cfg SamplingConfig) trace.Sampler {
+	routes := newRoutes(cfg.Routes)
+
+	var root trace.Sampler = &routeSampler{
+		routes:   routes,
+		fallback: trace.TraceIDRatioBased(rate),
+	}
+	if cfg.RateLimit > 0 {
+		root = newRateLimitSampler(root, cfg.RateLimit)
+	}
+
+	return &sampler{
+		routes:     routes,
+		head:       trace.ParentBased(root),
+		keepErrors: cfg.KeepErrors,
+	}
+}
+
+// sampler applies never-sampled routes ahead of the head sampler and turns
+// its drops into record-only decisions when errors are kept.
+type sampler struct {
+	routes     []routeRatio
+	head       trace.Sampler
+	keepErrors bool
+}
+
+func (s *sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	if r, ok := matchRoute(s.routes, &p); ok && r.ratio == 0 {
+		return trace.SamplingResult{
+			Decision:   trace.Drop,
+			Tracestate: oteltrace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+
+	res := s.head.ShouldSample(p)
+	if s.keepErrors && res.Decision == trace.Drop {
+		res.Decision = trace.RecordOnly
+	}
+
+	return res
+}
+
+func (s *sampler) Description() string {
+	return fmt.Sprintf("RouteSampler{%s,keepErrors:%t}", s.head.Description(), s.keepErrors)
+}
+
+// routeSampler samples root spans by the ratio of their route.
+type routeSampler struct {
+	routes   []routeRatio
+	fallback trace.Sampler
+}
+
+func (s *routeSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	if r, ok := matchRoute(s.routes, &p); ok {
+		return r.sampler.ShouldSample(p)
+	}
+
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *routeSampler) Description() string {
+	return fmt.Sprintf("Routes{%d,default:%s}", len(s.routes), s.fallback.Description())
+}
+
+// rateLimitSampler drops sampled decisions beyond a number per second, using
+// a token bucket holding up to one second of tokens.
+type rateLimitSampler struct {
+	next  trace.Sampler
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimitSampler(next trace.Sampler, perSecond float64) *rateLimitSampler {
+	burst := max(perSecond, 1)
+
+	return &rateLimitSampler{
+		next:   next,
+		rate:   perSecond,
+		burst:  burst,
+		now:    time.Now,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+func (s *rateLimitSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	res := s.next.ShouldSample(p)
+	if res.Decision == trace.RecordAndSample && !s.allow() {
+		res.Decision = trace.Drop
+	}
+
+	return res
+}
+
+func (s *rateLimitSampler) Description() string {
+	return fmt.Sprintf("RateLimit{%g/s,%s}", s.rate, s.next.Description())
+}
+
+// allow takes a token if one is available.
+func (s *rateLimitSampler) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.tokens = min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+
+	s.tokens--
+
+	return true
+}
+
+// keepErrorsProcessor passes sampled spans to the export processor, and
+// unsampled spans that ended with an error status as if they were sampled.
+type keepErrorsProcessor struct {
+	trace.SpanProcessor
+}
+
+func (p keepErrorsProcessor) OnEnd(s trace.ReadOnlySpan) {
+	switch {
+	case s.SpanContext().IsSampled():
+		p.SpanProcessor.OnEnd(s)
+	case s.Status().Code == codes.Error:
+		p.SpanProcessor.OnEnd(sampledSpan{s})
+	}
+}
+
+// sampledSpan reports a recorded span as sampled, so export processors
+// accept it.
+type sampledSpan struct {
+	trace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() oteltrace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// sampledParent returns a context carrying a sampled remote span context.
+func sampledParent() context.Context {
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+
+	return oteltrace.ContextWithRemoteSpanContext(context.Background(), sc)
+}
+
+func TestSampler_Routes(t *testing.T) {
+	s := newSampler(0, SamplingConfig{
+		Routes: map[string]float64{
+			"/-/":            0,
+			"/api/v1/quotes": 1,
+			"/api/":          0,
+		},
+	})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		kind   oteltrace.SpanKind
+		path   string
+		expect trace.SamplingDecision
+	}{
+		{"health path is dropped", context.Background(), oteltrace.SpanKindServer, "/-/ready", trace.Drop},
+		{"health path is dropped under a sampled parent", sampledParent(), oteltrace.SpanKindServer, "/-/live", trace.Drop},
+		{"longest prefix wins", context.Background(), oteltrace.SpanKindServer, "/api/v1/quotes/42", trace.RecordAndSample},
+		{"shorter prefix applies", context.Background(), oteltrace.SpanKindServer, "/api/v1/other", trace.Drop},
+		{"unmatched path uses the default rate", context.Background(), oteltrace.SpanKindServer, "/other", trace.Drop},
+		{"sampled parent is followed", sampledParent(), oteltrace.SpanKindServer, "/other", trace.RecordAndSample},
+		{"client spans ignore routes", sampledParent(), oteltrace.SpanKindClient, "/-/ready", trace.RecordAndSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := s.ShouldSample(trace.SamplingParameters{
+				ParentContext: tt.ctx,
+				TraceID:       oteltrace.TraceID{2},
+				Name:          "span",
+				Kind:          tt.kind,
+				Attributes:    []attribute.KeyValue{semconv.URLPath(tt.path)},
+			})
+			assert.Equal(t, tt.expect, res.Decision)
+		})
+	}
+}
+
+func TestRateLimitSampler(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	s := newRateLimitSampler(trace.AlwaysSample(), 2)
+	s.now = func() time.Time { return now }
+	s.last = now
+
+	sample := func() trace.SamplingDecision {
+		return s.ShouldSample(trace.SamplingParameters{ParentContext: context.Background()}).Decision
+	}
+
+	assert.Equal(t, trace.RecordAndSample, sample())
+	assert.Equal(t, trace.RecordAndSample, sample())
+	assert.Equal(t, trace.Drop, sample(), "burst is one second of tokens")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, trace.RecordAndSample, sample())
+	assert.Equal(t, trace.Drop, sample())
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, trace.RecordAndSample, sample())
+	assert.Equal(t, trace.RecordAndSample, sample())
+	assert.Equal(t, trace.Drop, sample(), "idle time does not grow the burst")
+}
+
+func TestKeepErrorsProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := trace.NewTracerProvider(
+		trace.WithSampler(newSampler(0, SamplingConfig{KeepErrors: true})),
+		trace.WithSpanProcessor(keepErrorsProcessor{trace.NewSimpleSpanProcessor(exporter)}),
+	)
+
+	tracer := provider.Tracer("test")
+
+	_, ok := tracer.Start(context.Background(), "ok")
+	ok.End()
+
+	ctx, failed := tracer.Start(context.Background(), "failed")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "failed", spans[0].Name)
+	assert.True(t, spans[0].SpanContext.IsSampled())
+}
+
+func TestKeepErrorsProcessor_Disabled(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := trace.NewTracerProvider(
+		trace.WithSampler(newSampler(0, SamplingConfig{})),
+		trace.WithSyncer(exporter),
+	)
+
+	_, span := provider.Tracer("test").Start(context.Background(), "failed")
+	assert.False(t, span.IsRecording())
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+
+	assert.Empty(t, exporter.GetSpans())
+}
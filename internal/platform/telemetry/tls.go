@@ -0,0 +1,121 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TLSConfig configures TLS for https collector endpoints. Zero values use
+// the system roots, no client certificate, the endpoint host as server name
+// and TLS 1.2 as the minimum version.
+type TLSConfig struct {
+	CAFile     string // PEM bundle of CAs trusted for the collector, instead of the system roots
+	CertFile   string // PEM client certificate for mutual TLS; requires KeyFile
+	KeyFile    string // PEM private key of CertFile
+	ServerName string // Overrides the name verified against the collector certificate
+	MinVersion string // "1.2" or "1.3"
+}
+
+// tlsVersions maps MinVersion values to crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// load builds the crypto/tls configuration, reading the CA bundle and the
+// client key pair from disk.
+func (c *TLSConfig) load() (*tls.Config, error) {
+	minVersion, ok := tlsVersions[c.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS min version %q", c.MinVersion)
+	}
+
+	cfg := &tls.Config{
+		MinVersion: minVersion,
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", c.CAFile)
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, errors.New("client certificate requires both cert and key files")
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// exportSettings are the connection settings shared by the OTLP exporters
+// of every signal.
+type exportSettings struct {
+	endpoint  string            // Endpoint URL
+	host      string            // Host and port of the endpoint
+	basePath  string            // Endpoint path without a trailing slash, prefixed to OTLP/HTTP signal paths
+	tlsConfig *tls.Config       // Nil for a plaintext (http) endpoint
+	headers   map[string]string // Sent with every export request
+}
+
+// newExportSettings validates the endpoint and builds the TLS configuration
+// and request headers from cfg.
+func newExportSettings(cfg *Config) (*exportSettings, error) {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing endpoint: %w", err)
+	}
+
+	settings := &exportSettings{
+		endpoint: cfg.Endpoint,
+		host:     u.Host,
+		basePath: strings.TrimSuffix(u.Path, "/"),
+		headers:  maps.Clone(cfg.Headers),
+	}
+
+	switch u.Scheme {
+	case "https":
+		settings.tlsConfig, err = cfg.TLS.load()
+		if err != nil {
+			return nil, fmt.Errorf("configuring TLS: %w", err)
+		}
+	case "http":
+		// Plaintext; TLS options do not apply
+	default:
+		return nil, fmt.Errorf("endpoint scheme must be http or https, got %q", u.Scheme)
+	}
+
+	if cfg.BearerToken != "" {
+		if settings.headers == nil {
+			settings.headers = make(map[string]string, 1)
+		}
+
+		settings.headers["authorization"] = "Bearer " + cfg.BearerToken
+	}
+
+	return settings, nil
+}
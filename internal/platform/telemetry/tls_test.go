@@ -0,0 +1,294 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// testPKI is a CA with a server and a client certificate, written as PEM
+// files in a temporary directory.
+type testPKI struct {
+	caFile     string
+	certFile   string // Client certificate
+	keyFile    string // Client key
+	serverCert tls.Certificate
+	pool       *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	issue := func(serial int64, tmpl *x509.Certificate) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		tmpl.SerialNumber = big.NewInt(serial)
+		tmpl.NotBefore = caTmpl.NotBefore
+		tmpl.NotAfter = caTmpl.NotAfter
+		tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCertPEM, serverKeyPEM := issue(2, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "collector.test"},
+		DNSNames:    []string{"collector.test"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientCertPEM, clientKeyPEM := issue(3, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "service"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	pki := &testPKI{
+		caFile:     filepath.Join(dir, "ca.pem"),
+		certFile:   filepath.Join(dir, "client.pem"),
+		keyFile:    filepath.Join(dir, "client-key.pem"),
+		serverCert: serverCert,
+		pool:       x509.NewCertPool(),
+	}
+	pki.pool.AddCert(caCert)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	require.NoError(t, os.WriteFile(pki.caFile, caPEM, 0o600))
+	require.NoError(t, os.WriteFile(pki.certFile, clientCertPEM, 0o600))
+	require.NoError(t, os.WriteFile(pki.keyFile, clientKeyPEM, 0o600))
+
+	return pki
+}
+
+// testCollector is an OTLP/gRPC collector stand-in recording the metadata of
+// each export request.
+type testCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu      sync.Mutex
+	spans   int
+	headers []metadata.MD
+}
+
+func (c *testCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.record(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			c.spans += len(ss.GetSpans())
+		}
+	}
+
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+// metricsService adapts the collector to the metrics service, whose Export
+// method has a different signature.
+type metricsService struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+	c *testCollector
+}
+
+func (s metricsService) Export(ctx context.Context, _ *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	s.c.record(ctx)
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *testCollector) record(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.headers = append(c.headers, md)
+}
+
+// startTLSCollector serves a collector requiring client certificates issued
+// by the PKI's CA, and returns its https endpoint.
+func startTLSCollector(t *testing.T, pki *testPKI) (string, *testCollector) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{pki.serverCert},
+		ClientCAs:    pki.pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	})
+
+	collector := &testCollector{}
+	srv := grpc.NewServer(grpc.Creds(creds))
+	collectortrace.RegisterTraceServiceServer(srv, collector)
+	collectormetrics.RegisterMetricsServiceServer(srv, metricsService{c: collector})
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	return "https://" + lis.Addr().String(), collector
+}
+
+func TestNew_ExportsOverMutualTLSWithAuth(t *testing.T) {
+	pki := newTestPKI(t)
+	endpoint, collector := startTLSCollector(t, pki)
+
+	provider, err := New(context.Background(), &Config{
+		Enabled:      true,
+		Endpoint:     endpoint,
+		ServiceName:  "test-service",
+		SamplingRate: 1,
+		TLS: TLSConfig{
+			CAFile:     pki.caFile,
+			CertFile:   pki.certFile,
+			KeyFile:    pki.keyFile,
+			ServerName: "collector.test",
+			MinVersion: "1.3",
+		},
+		Headers:     map[string]string{"x-api-key": "key-123"},
+		BearerToken: "token-456",
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	assert.Equal(t, 1, collector.spans)
+	require.NotEmpty(t, collector.headers)
+
+	for _, md := range collector.headers {
+		assert.Equal(t, []string{"key-123"}, md.Get("x-api-key"))
+		assert.Equal(t, []string{"Bearer token-456"}, md.Get("authorization"))
+	}
+}
+
+func TestNewExportSettings(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantTLS bool
+		wantErr string
+	}{
+		{
+			name: "http is plaintext",
+			cfg:  Config{Endpoint: "http://collector:4317", TLS: TLSConfig{MinVersion: "1.2"}},
+		},
+		{
+			name:    "https uses TLS",
+			cfg:     Config{Endpoint: "https://collector:4317", TLS: TLSConfig{CAFile: pki.caFile}},
+			wantTLS: true,
+		},
+		{
+			name:    "unsupported scheme",
+			cfg:     Config{Endpoint: "grpc://collector:4317"},
+			wantErr: "endpoint scheme must be http or https",
+		},
+		{
+			name:    "missing CA file",
+			cfg:     Config{Endpoint: "https://collector:4317", TLS: TLSConfig{CAFile: filepath.Join(t.TempDir(), "nope.pem")}},
+			wantErr: "reading CA file",
+		},
+		{
+			name:    "CA file without certificates",
+			cfg:     Config{Endpoint: "https://collector:4317", TLS: TLSConfig{CAFile: pki.keyFile}},
+			wantErr: "no certificates found",
+		},
+		{
+			name:    "cert without key",
+			cfg:     Config{Endpoint: "https://collector:4317", TLS: TLSConfig{CertFile: pki.certFile}},
+			wantErr: "requires both cert and key files",
+		},
+		{
+			name:    "unsupported min version",
+			cfg:     Config{Endpoint: "https://collector:4317", TLS: TLSConfig{MinVersion: "1.1"}},
+			wantErr: "unsupported TLS min version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := newExportSettings(&tt.cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTLS, settings.tlsConfig != nil)
+		})
+	}
+}
+
+func TestNewExportSettings_BearerTokenDoesNotModifyHeaders(t *testing.T) {
+	headers := map[string]string{"x-api-key": "key"}
+
+	settings, err := newExportSettings(&Config{
+		Endpoint:    "http://collector:4317",
+		Headers:     headers,
+		BearerToken: "token",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"x-api-key": "key", "authorization": "Bearer token"}, settings.headers)
+	assert.Equal(t, map[string]string{"x-api-key": "key"}, headers)
+}
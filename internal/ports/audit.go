@@ -0,0 +1,98 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Audit actions recorded by the template. Services add their own.
+const (
+	// AuditActionAuthorize is an authorization decision by the auth middleware.
+	AuditActionAuthorize = "authorize"
+
+	// AuditActionCommit is the commit of a request's staged write actions.
+	AuditActionCommit = "commit"
+)
+
+// Audit decisions for AuditActionAuthorize events.
+const (
+	AuditDecisionAllow = "allow"
+	AuditDecisionDeny  = "deny"
+)
+
+// AuditOutcome is the result of an audited action.
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess indicates the action completed.
+	AuditOutcomeSuccess AuditOutcome = "success"
+
+	// AuditOutcomeFailure indicates the action was attempted and failed.
+	AuditOutcomeFailure AuditOutcome = "failure"
+
+	// AuditOutcomeDenied indicates the action was refused by authorization.
+	AuditOutcomeDenied AuditOutcome = "denied"
+)
+
+// AuditEvent records who did what, to which resource, and with what result.
+type AuditEvent struct {
+	// Time is when the event occurred. Set by the AuditLogger if zero.
+	Time time.Time `json:"time"`
+
+	// Subject is the authenticated caller (sub claim), empty if anonymous.
+	Subject string `json:"subject"`
+
+	// Action is what was attempted, e.g. AuditActionAuthorize.
+	Action string `json:"action"`
+
+	// Resource is what the action applied to, e.g. "GET /api/v1/quotes/:id".
+	Resource string `json:"resource"`
+
+	// Decision is the authorization decision, for AuditActionAuthorize events.
+	Decision string `json:"decision,omitempty"`
+
+	// Outcome is the result of the action.
+	Outcome AuditOutcome `json:"outcome"`
+
+	// Reason explains a denial or failure.
+	Reason string `json:"reason,omitempty"`
+
+	// RequestID and CorrelationID tie the event to the request logs.
+	RequestID     string `json:"request_id,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// Details holds action-specific fields, e.g. the required role.
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// AuditLogger records security-relevant events to an append-only trail,
+// separate from application logs, for security and compliance review.
+//
+// Implementations must be safe for concurrent use. Record should not
+// block for long; callers treat a returned error as a failed write that
+// is reported but does not fail the audited action.
+type AuditLogger interface {
+	// Record appends the event to the audit trail.
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+// auditLoggerKey is the context key for the request's AuditLogger.
+type auditLoggerKey struct{}
+
+// WithAuditLogger stores the AuditLogger used for the request in ctx.
+func WithAuditLogger(ctx context.Context, logger AuditLogger) context.Context {
+	return context.WithValue(ctx, auditLoggerKey{}, logger)
+}
+
+// GetAuditLogger retrieves the AuditLogger from context, or nil if auditing is off.
+func GetAuditLogger(ctx context.Context) AuditLogger {
+	if ctx == nil {
+		return nil
+	}
+
+	if logger, ok := ctx.Value(auditLoggerKey{}).(AuditLogger); ok {
+		return logger
+	}
+
+	return nil
+}